@@ -245,7 +245,7 @@ var File_auth_proto protoreflect.FileDescriptor
 const file_auth_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
-	"auth.proto\x12\x03web\x1a\x1bbuf/validate/validate.proto\x1a\x17validate/validate.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x0fconstants.proto\"\x86\x01\n" +
+	"auth.proto\x12\x03web\x1a\x1bbuf/validate/validate.proto\x1a\x17validate/validate.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x0fconstants.proto\x1a\roptions.proto\"\x86\x01\n" +
 	"\x17GetLoginSignTextRequest\x12F\n" +
 	"\x0fblockchain_type\x18\x01 \x01(\x0e2\x13.web.BlockChainTypeB\b\xbaH\x05\x82\x01\x02\x10\x01R\x0eblockchainType\x12#\n" +
 	"\aaddress\x18\x02 \x01(\tB\t\xfaB\x06r\x04\x10 \x18@R\aaddress\".\n" +
@@ -260,10 +260,10 @@ const file_auth_proto_rawDesc = "" +
 	"\xfaB\ar\x05\x10 \x18\x80\x02R\tsignature\x12#\n" +
 	"\aaddress\x18\x04 \x01(\tB\t\xfaB\x06r\x04\x10 \x18@R\aaddress\"-\n" +
 	"\x15LoginByWalletResponse\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token2\xe2\x01\n" +
-	"\x04Auth\x12e\n" +
-	"\rLoginByWallet\x12\x19.web.LoginByWalletRequest\x1a\x1a.web.LoginByWalletResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/auth/login/wallet\x12s\n" +
-	"\x15GetLoginSignatureText\x12\x1c.web.GetLoginSignTextRequest\x1a\x1d.web.GetLoginSignTextResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/auth/login/sign_textB1Z/github.com/carv-protocol/kratos-ddd/api/web;webb\x06proto3"
+	"\x05token\x18\x01 \x01(\tR\x05token2\xea\x01\n" +
+	"\x04Auth\x12i\n" +
+	"\rLoginByWallet\x12\x19.web.LoginByWalletRequest\x1a\x1a.web.LoginByWalletResponse\"!\xc8\xf3\x18\x04\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/auth/login/wallet\x12w\n" +
+	"\x15GetLoginSignatureText\x12\x1c.web.GetLoginSignTextRequest\x1a\x1d.web.GetLoginSignTextResponse\"!\xc8\xf3\x18\x04\x82\xd3\xe4\x93\x02\x17\x12\x15/auth/login/sign_textB1Z/github.com/carv-protocol/kratos-ddd/api/web;webb\x06proto3"
 
 var (
 	file_auth_proto_rawDescOnce sync.Once
@@ -305,6 +305,7 @@ func file_auth_proto_init() {
 		return
 	}
 	file_constants_proto_init()
+	file_options_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
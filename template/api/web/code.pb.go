@@ -35,6 +35,12 @@ const (
 	ErrorReason_AUTH_SIGNATURE_TEXT_EXPIRED       ErrorReason = 10004
 	ErrorReason_USER_NOT_FOUND                    ErrorReason = 10101
 	ErrorReason_USER_ALREADY_EXISTS               ErrorReason = 10102
+	ErrorReason_INTERCEPT_RULES_INVALID           ErrorReason = 10201
+	ErrorReason_INTERCEPT_RULES_NOT_FOUND         ErrorReason = 10202
+	ErrorReason_INTERCEPT_RULES_CONFLICT          ErrorReason = 10203
+	ErrorReason_TASK_QUEUE_NOT_FOUND              ErrorReason = 10301
+	ErrorReason_TASK_NOT_FOUND                    ErrorReason = 10302
+	ErrorReason_TASK_STATE_INVALID                ErrorReason = 10303
 )
 
 // Enum value maps for ErrorReason.
@@ -50,6 +56,12 @@ var (
 		10004: "AUTH_SIGNATURE_TEXT_EXPIRED",
 		10101: "USER_NOT_FOUND",
 		10102: "USER_ALREADY_EXISTS",
+		10201: "INTERCEPT_RULES_INVALID",
+		10202: "INTERCEPT_RULES_NOT_FOUND",
+		10203: "INTERCEPT_RULES_CONFLICT",
+		10301: "TASK_QUEUE_NOT_FOUND",
+		10302: "TASK_NOT_FOUND",
+		10303: "TASK_STATE_INVALID",
 	}
 	ErrorReason_value = map[string]int32{
 		"_":                                 0,
@@ -62,6 +74,12 @@ var (
 		"AUTH_SIGNATURE_TEXT_EXPIRED":       10004,
 		"USER_NOT_FOUND":                    10101,
 		"USER_ALREADY_EXISTS":               10102,
+		"INTERCEPT_RULES_INVALID":           10201,
+		"INTERCEPT_RULES_NOT_FOUND":         10202,
+		"INTERCEPT_RULES_CONFLICT":          10203,
+		"TASK_QUEUE_NOT_FOUND":              10301,
+		"TASK_NOT_FOUND":                    10302,
+		"TASK_STATE_INVALID":                10303,
 	}
 )
 
@@ -97,7 +115,7 @@ var File_code_proto protoreflect.FileDescriptor
 const file_code_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
-	"code.proto\x12\x03web\x1a\x13errors/errors.proto*\xce\x02\n" +
+	"code.proto\x12\x03web\x1a\x13errors/errors.proto*\x98\x04\n" +
 	"\vErrorReason\x12\x05\n" +
 	"\x01_\x10\x00\x12\x19\n" +
 	"\x0eINVALID_PARAMS\x10\x90\x03\x1a\x04\xa8E\x90\x03\x12\x1a\n" +
@@ -108,7 +126,13 @@ const file_code_proto_rawDesc = "" +
 	"\x1bAUTH_SIGNATURE_TEXT_INVALID\x10\x93N\x1a\x04\xa8E\x90\x03\x12&\n" +
 	"\x1bAUTH_SIGNATURE_TEXT_EXPIRED\x10\x94N\x1a\x04\xa8E\x90\x03\x12\x19\n" +
 	"\x0eUSER_NOT_FOUND\x10\xf5N\x1a\x04\xa8E\x94\x03\x12\x1e\n" +
-	"\x13USER_ALREADY_EXISTS\x10\xf6N\x1a\x04\xa8E\x94\x03\x1a\x04\xa0E\xf4\x03B1Z/github.com/carv-protocol/kratos-ddd/api/web;webb\x06proto3"
+	"\x13USER_ALREADY_EXISTS\x10\xf6N\x1a\x04\xa8E\x94\x03\x12\"\n" +
+	"\x17INTERCEPT_RULES_INVALID\x10\xd9O\x1a\x04\xa8E\x90\x03\x12$\n" +
+	"\x19INTERCEPT_RULES_NOT_FOUND\x10\xdaO\x1a\x04\xa8E\x94\x03\x12#\n" +
+	"\x18INTERCEPT_RULES_CONFLICT\x10\xdbO\x1a\x04\xa8E\x99\x03\x12\x1f\n" +
+	"\x14TASK_QUEUE_NOT_FOUND\x10\xbdP\x1a\x04\xa8E\x94\x03\x12\x19\n" +
+	"\x0eTASK_NOT_FOUND\x10\xbeP\x1a\x04\xa8E\x94\x03\x12\x1d\n" +
+	"\x12TASK_STATE_INVALID\x10\xbfP\x1a\x04\xa8E\x90\x03\x1a\x04\xa0E\xf4\x03B1Z/github.com/carv-protocol/kratos-ddd/api/web;webb\x06proto3"
 
 var (
 	file_code_proto_rawDescOnce sync.Once
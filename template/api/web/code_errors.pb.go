@@ -132,3 +132,75 @@ func IsUserAlreadyExists(err error) bool {
 func ErrorUserAlreadyExists(format string, args ...interface{}) *errors.Error {
 	return errors.New(404, ErrorReason_USER_ALREADY_EXISTS.String(), fmt.Sprintf(format, args...))
 }
+
+func IsInterceptRulesInvalid(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == ErrorReason_INTERCEPT_RULES_INVALID.String() && e.Code == 400
+}
+
+func ErrorInterceptRulesInvalid(format string, args ...interface{}) *errors.Error {
+	return errors.New(400, ErrorReason_INTERCEPT_RULES_INVALID.String(), fmt.Sprintf(format, args...))
+}
+
+func IsInterceptRulesNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == ErrorReason_INTERCEPT_RULES_NOT_FOUND.String() && e.Code == 404
+}
+
+func ErrorInterceptRulesNotFound(format string, args ...interface{}) *errors.Error {
+	return errors.New(404, ErrorReason_INTERCEPT_RULES_NOT_FOUND.String(), fmt.Sprintf(format, args...))
+}
+
+func IsInterceptRulesConflict(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == ErrorReason_INTERCEPT_RULES_CONFLICT.String() && e.Code == 409
+}
+
+func ErrorInterceptRulesConflict(format string, args ...interface{}) *errors.Error {
+	return errors.New(409, ErrorReason_INTERCEPT_RULES_CONFLICT.String(), fmt.Sprintf(format, args...))
+}
+
+func IsTaskQueueNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == ErrorReason_TASK_QUEUE_NOT_FOUND.String() && e.Code == 404
+}
+
+func ErrorTaskQueueNotFound(format string, args ...interface{}) *errors.Error {
+	return errors.New(404, ErrorReason_TASK_QUEUE_NOT_FOUND.String(), fmt.Sprintf(format, args...))
+}
+
+func IsTaskNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == ErrorReason_TASK_NOT_FOUND.String() && e.Code == 404
+}
+
+func ErrorTaskNotFound(format string, args ...interface{}) *errors.Error {
+	return errors.New(404, ErrorReason_TASK_NOT_FOUND.String(), fmt.Sprintf(format, args...))
+}
+
+func IsTaskStateInvalid(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := errors.FromError(err)
+	return e.Reason == ErrorReason_TASK_STATE_INVALID.String() && e.Code == 400
+}
+
+func ErrorTaskStateInvalid(format string, args ...interface{}) *errors.Error {
+	return errors.New(400, ErrorReason_TASK_STATE_INVALID.String(), fmt.Sprintf(format, args...))
+}
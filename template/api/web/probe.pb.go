@@ -184,7 +184,7 @@ var File_probe_proto protoreflect.FileDescriptor
 
 const file_probe_proto_rawDesc = "" +
 	"\n" +
-	"\vprobe.proto\x12\x03web\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\"0\n" +
+	"\vprobe.proto\x12\x03web\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\roptions.proto\"0\n" +
 	"\x16ReadinessProbeResponse\x12\x16\n" +
 	"\x06status\x18\x01 \x01(\tR\x06status\"\xbe\x02\n" +
 	"\x14HealthStatusResponse\x12\x16\n" +
@@ -198,12 +198,12 @@ const file_probe_proto_rawDesc = "" +
 	"\x05error\x18\x03 \x01(\tR\x05error\x1ah\n" +
 	"\x0fComponentsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12?\n" +
-	"\x05value\x18\x02 \x01(\v2).web.HealthStatusResponse.ComponentHealthR\x05value:\x028\x012\x8b\x02\n" +
-	"\x05Probe\x12R\n" +
-	"\fhealthStatus\x12\x16.google.protobuf.Empty\x1a\x19.web.HealthStatusResponse\"\x0f\x82\xd3\xe4\x93\x02\t\x12\a/health\x12R\n" +
+	"\x05value\x18\x02 \x01(\v2).web.HealthStatusResponse.ComponentHealthR\x05value:\x028\x012\x97\x02\n" +
+	"\x05Probe\x12V\n" +
+	"\fhealthStatus\x12\x16.google.protobuf.Empty\x1a\x19.web.HealthStatusResponse\"\x13\xc8\xf3\x18\x05\x82\xd3\xe4\x93\x02\t\x12\a/health\x12V\n" +
 	"\n" +
-	"healthLive\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/health/live\x12Z\n" +
-	"\vhealthReady\x12\x17.google.protobuf.Struct\x1a\x1b.web.ReadinessProbeResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/health/readyB1Z/github.com/carv-protocol/kratos-ddd/api/web;webb\x06proto3"
+	"healthLive\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x18\xc8\xf3\x18\x05\x82\xd3\xe4\x93\x02\x0e\x12\f/health/live\x12^\n" +
+	"\vhealthReady\x12\x17.google.protobuf.Struct\x1a\x1b.web.ReadinessProbeResponse\"\x19\xc8\xf3\x18\x05\x82\xd3\xe4\x93\x02\x0f\x12\r/health/readyB1Z/github.com/carv-protocol/kratos-ddd/api/web;webb\x06proto3"
 
 var (
 	file_probe_proto_rawDescOnce sync.Once
@@ -247,6 +247,7 @@ func file_probe_proto_init() {
 	if File_probe_proto != nil {
 		return
 	}
+	file_options_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
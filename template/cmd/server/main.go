@@ -1,12 +1,17 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"flag"
 	"fmt"
 	"os"
 	"time"
 
 	"github.com/go-kratos/kratos/v2/encoding/json"
+	"github.com/pkg/errors"
+	"github.com/seanbit/kratos/template/internal/conf"
 	"github.com/seanbit/kratos/template/internal/global"
 	"github.com/seanbit/kratos/webkit"
 	"github.com/seanbit/kratos/webkit/transport/asynq"
@@ -52,7 +57,7 @@ func init() {
 	}
 }
 
-func newApp(gs *grpc.Server, hs *http.Server, asynqs *asynq.Server, crontor *crontab.Executor) *kratos.App {
+func newApp(gs *grpc.Server, hs *http.Server, asynqs *asynq.Server, scheduler *asynq.Scheduler, crontor *crontab.Executor, outbox *webkit.OutboxRelay) *kratos.App {
 	return kratos.New(
 		kratos.ID(id),
 		kratos.Name(Name),
@@ -62,7 +67,9 @@ func newApp(gs *grpc.Server, hs *http.Server, asynqs *asynq.Server, crontor *cro
 			gs,
 			hs,
 			asynqs,
+			scheduler,
 			crontor,
+			outbox,
 		),
 		kratos.StopTimeout(time.Second*300),
 	)
@@ -80,10 +87,21 @@ func main() {
 	webkit.InitLogger(Name, Version, int(bc.LogLevel))
 
 	// 初始化Metrics
-	if err := webkit.InitMetrics(Name); err != nil {
+	if err := webkit.InitMetrics(Name, metricsOptions(bc.Metrics, bc.Env.String())...); err != nil {
 		log.Errorf("InitMetrics: %+v", err)
 		panic(err)
 	}
+	if err := webkit.RegisterRuntimeMetrics(); err != nil {
+		log.Errorf("RegisterRuntimeMetrics: %+v", err)
+		panic(err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := webkit.ShutdownMetrics(ctx); err != nil {
+			log.Errorf("ShutdownMetrics: %+v", err)
+		}
+	}()
 
 	// 初始化 sentry
 	err := webkit.InitSentry(Name, Version, bc.Env.String(), bc.Sentry.GetDsn(), bc.Sentry.GetAttachStackTrace())
@@ -93,11 +111,23 @@ func main() {
 	}
 
 	// 初始化分布式追踪
-	if err := webkit.InitTracerProvider(bc.Tracing.GetType(), bc.Tracing.GetHost(), int(bc.Tracing.GetPort()),
-		Name, Version, bc.Env.String()); err != nil {
+	tracerOpts, err := tracerOptions(bc.Tracing, bc.Env.String())
+	if err != nil {
 		log.Errorf("InitTracerProvider failed: %+v", err)
 		panic(err)
 	}
+	shutdownTracer, err := webkit.InitTracerProvider(Name, tracerOpts...)
+	if err != nil {
+		log.Errorf("InitTracerProvider failed: %+v", err)
+		panic(err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracer(ctx); err != nil {
+			log.Errorf("ShutdownTracer: %+v", err)
+		}
+	}()
 
 	app, cleanup, err := wireApp(bc.Server, bc.Data, bc.S3, bc.GeoIp, bc.Alarm, bc.Auth, log.GetLogger())
 	if err != nil {
@@ -110,3 +140,61 @@ func main() {
 		panic(err)
 	}
 }
+
+// metricsOptions 将 metrics 配置转换为 InitMetrics 选项，未配置 exporters 时仅使用 Prometheus
+func metricsOptions(c *conf.Metrics, env string) []webkit.MetricsOption {
+	opts := []webkit.MetricsOption{webkit.WithMetricsResource(Version, env)}
+	if len(c.GetExporters()) > 0 {
+		opts = append(opts, webkit.WithMetricsExporters(c.GetExporters()...))
+	}
+	if c.GetOtlpEndpoint() != "" {
+		opts = append(opts, webkit.WithMetricsOTLPEndpoint(c.GetOtlpEndpoint(), c.GetOtlpInsecure()))
+	}
+	if len(c.GetOtlpHeaders()) > 0 {
+		opts = append(opts, webkit.WithMetricsOTLPHeaders(c.GetOtlpHeaders()))
+	}
+	if c.GetPushInterval() != nil {
+		opts = append(opts, webkit.WithMetricsPushInterval(c.GetPushInterval().AsDuration()))
+	}
+	for _, h := range c.GetHistograms() {
+		opts = append(opts, webkit.WithMetricsBuckets(h.GetName(), h.GetBuckets()...))
+	}
+	return opts
+}
+
+// tracerOptions 将 tracing 配置转换为 InitTracerProvider 选项，兼容旧的 host/port 配置
+func tracerOptions(c *conf.Tracing, env string) ([]webkit.TracerOption, error) {
+	opts := []webkit.TracerOption{
+		webkit.WithTracerResource(Version, env),
+		webkit.WithTracerExporter(c.GetType()),
+	}
+	endpoint, insecure := c.GetEndpoint(), c.GetInsecure()
+	if endpoint == "" && c.GetHost() != "" {
+		endpoint, insecure = fmt.Sprintf("%s:%d", c.GetHost(), c.GetPort()), true
+	}
+	if endpoint != "" {
+		opts = append(opts, webkit.WithTracerEndpoint(endpoint, insecure))
+	}
+	if c.GetCaFile() != "" {
+		pem, err := os.ReadFile(c.GetCaFile())
+		if err != nil {
+			return nil, errors.Wrap(err, "read tracing ca file")
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.Errorf("invalid tracing ca file: %s", c.GetCaFile())
+		}
+		opts = append(opts, webkit.WithTracerTLSConfig(&tls.Config{RootCAs: pool}))
+	}
+	if len(c.GetHeaders()) > 0 {
+		opts = append(opts, webkit.WithTracerHeaders(c.GetHeaders()))
+	}
+	if c.GetSampler() != "" || c.GetSampleRatio() != nil {
+		ratio := 1.0
+		if c.GetSampleRatio() != nil {
+			ratio = c.GetSampleRatio().GetValue()
+		}
+		opts = append(opts, webkit.WithTracerSampler(c.GetSampler(), ratio))
+	}
+	return opts, nil
+}
@@ -35,38 +35,88 @@ func wireApp(confServer *conf.Server, confData *conf.Data, s3 *conf.S3, geoIp *c
 	iHealthRepo := data.NewHealthRepo(dataProvider, dataProvider, logger)
 	probe := biz.NewProbe(iHealthRepo)
 	probeService := service.NewProbeService(probe)
-	grpcServer := server.NewGRPCServer(confServer, probeService, logger)
-	iAuthRepo := data.NewAuthRepo(dataProvider, dataProvider)
-	client, err := server.NewAsynqClient(confServer)
+	iAlarmMessageRepo := data.NewAlarmMessageRepo(dataProvider, dataProvider, logger)
+	iAlarmRepo, cleanup2, err := data.NewAlarm(alarm, iAlarmMessageRepo)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	iTransaction := data.NewTransaction(dataProvider)
+	iInterceptRuleRepo := data.NewInterceptRuleRepo(dataProvider, dataProvider)
+	outbox := data.NewOutbox()
+	eventPublisher := data.NewEventPublisher(dataProvider, outbox)
+	interceptRule := biz.NewInterceptRule(iTransaction, iInterceptRuleRepo, eventPublisher)
+	interceptAdminService := service.NewInterceptAdminService(confServer, interceptRule)
+	inspector, cleanup3, err := server.NewAsynqInspector(confServer)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	admin := server.NewAsynqAdmin(inspector)
+	taskAdminService := service.NewTaskAdminService(confServer, admin)
+	trafficInterceptor, cleanup4 := server.NewTrafficInterceptor(confServer, dataProvider, iAlarmRepo)
+	loadShedder, err := server.NewLoadShedder(confServer)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	rateLimiter, err := server.NewRateLimiter(confServer, dataProvider)
 	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	iAuthLogRepo := data.NewAuthLogRepo(dataProvider, dataProvider, client)
+	iAuthRepo := data.NewAuthRepo(dataProvider, dataProvider)
+	iAuthLogRepo := data.NewAuthLogRepo(dataProvider, dataProvider)
 	s3Client := infra.NewS3Client(s3)
 	iGeoIp, err := data.NewGeoIP(s3Client, geoIp)
 	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	bizAuth := biz.NewAuth(auth, iAuthRepo, iAuthLogRepo, iGeoIp)
+	bizAuth := biz.NewAuth(auth, iTransaction, iAuthRepo, iAuthLogRepo, iGeoIp, eventPublisher)
 	userAuth := middlewares.NewUserAuth(bizAuth)
+	grpcServer := server.NewGRPCServer(confServer, probeService, interceptAdminService, userAuth, logger, trafficInterceptor, loadShedder, rateLimiter)
 	httpBuilder := middlewares.NewHttpBuilder(userAuth)
-	iAlarmMessageRepo := data.NewAlarmMessageRepo(dataProvider, dataProvider, logger)
-	iAlarmRepo, cleanup2, err := data.NewAlarm(alarm, iAlarmMessageRepo)
+	authService := service.NewAuthService(bizAuth)
+	httpServer := server.NewHTTPServer(confServer, logger, httpBuilder, probeService, iAlarmRepo, authService, interceptAdminService, taskAdminService, trafficInterceptor, loadShedder, rateLimiter)
+	eventService := service.NewEventService(bizAuth, interceptRule)
+	client, err := server.NewAsynqClient(confServer)
 	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	authService := service.NewAuthService(bizAuth)
-	httpServer := server.NewHTTPServer(confServer, logger, httpBuilder, probeService, iAlarmRepo, authService)
-	eventHandlerServer := service.NewEventService(bizAuth)
-	asynqServer := server.NewAsynqServer(confServer, logger, eventHandlerServer)
+	periodicTest := crontab.NewPeriodicTest()
+	asynqServer, err := server.NewAsynqServer(confServer, logger, client, eventService, periodicTest)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	periodicTaskRegister := crontab.NewPeriodicTaskRegister()
+	scheduler := server.NewAsynqScheduler(confServer, logger, periodicTaskRegister)
 	jobTest := crontab.NewJobTest()
 	jobRegister := crontab.NewJobRegister(jobTest)
 	executor := crontab2.NewServer(jobRegister)
-	app := newApp(grpcServer, httpServer, asynqServer, executor)
+	outboxRelay := server.NewOutboxRelay(confServer, dataProvider, client, logger)
+	app := newApp(grpcServer, httpServer, asynqServer, scheduler, executor, outboxRelay)
 	return app, func() {
+		cleanup4()
+		cleanup3()
 		cleanup2()
 		cleanup()
 	}, nil
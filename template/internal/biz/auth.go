@@ -13,6 +13,7 @@ import (
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/pkg/errors"
+	"github.com/seanbit/kratos/template/api/event"
 	"github.com/seanbit/kratos/template/internal/conf"
 	"github.com/seanbit/kratos/template/internal/data/model"
 	"github.com/seanbit/kratos/template/internal/static"
@@ -20,6 +21,7 @@ import (
 	"github.com/seanbit/kratos/webkit"
 	"github.com/seanbit/kratos/webkit/cryptos"
 	"github.com/segmentio/ksuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
@@ -41,14 +43,16 @@ type UserLoginLog struct {
 }
 
 type IAuthLogRepo interface {
-	PublishUserLoginEvent(ctx context.Context, userLoginLog *UserLoginLog) error
 	SaveUserLoginLog(ctx context.Context, userLoginLog *model.UserLoginLog) error
+	SaveUserLoginLogs(ctx context.Context, userLoginLogs []*model.UserLoginLog) error
 }
 
 type Auth struct {
+	tx          ITransaction
 	authRepo    IAuthRepo
 	authLogRepo IAuthLogRepo
 	geoIp       IGeoIp
+	events      webkit.EventPublisher
 	jwtKey      struct {
 		private ed25519.PrivateKey
 		public  ed25519.PublicKey
@@ -56,15 +60,17 @@ type Auth struct {
 	config *conf.Auth
 }
 
-func NewAuth(config *conf.Auth, authRepo IAuthRepo, authLogRepo IAuthLogRepo, geoIp IGeoIp) *Auth {
+func NewAuth(config *conf.Auth, tx ITransaction, authRepo IAuthRepo, authLogRepo IAuthLogRepo, geoIp IGeoIp, events webkit.EventPublisher) *Auth {
 	privateKey, publicKey, err := web3.LoadEd25519Keys(config.JwtKey_25519, web3.Ed25519KeyPairEncodeHex)
 	if err != nil {
 		panic(fmt.Sprintf("Failed to load keys: %v\n", err))
 	}
 	return &Auth{
+		tx:          tx,
 		authRepo:    authRepo,
 		authLogRepo: authLogRepo,
 		geoIp:       geoIp,
+		events:      events,
 		jwtKey: struct {
 			private ed25519.PrivateKey
 			public  ed25519.PublicKey
@@ -103,15 +109,13 @@ func (biz *Auth) LoginByWallet(ctx context.Context, blockchainType, originText,
 	if err != nil {
 		return nil, err
 	}
-	if userAuthInfo == nil {
+	newUser := userAuthInfo == nil
+	if newUser {
 		userAuthInfo = &model.UserAuthInfo{
 			UserID:   ksuid.New().String(),
 			AuthType: authType,
 			AuthInfo: address,
 		}
-		if err := biz.authRepo.SetUserAuthInfo(ctx, userAuthInfo); err != nil {
-			return nil, err
-		}
 	}
 	loginInfo := &LoginInfo{
 		UserInfo: &webkit.UserInfo{
@@ -125,15 +129,24 @@ func (biz *Auth) LoginByWallet(ctx context.Context, blockchainType, originText,
 	if err != nil {
 		return nil, err
 	}
-	loginLog := &UserLoginLog{
-		UserId:     userAuthInfo.UserID,
+	loginEvent := &event.UserLogin{
 		AuthType:   authType,
-		LoginIp:    loginIp,
-		LoginTime:  loginTime,
+		UserId:     userAuthInfo.UserID,
+		Ip:         loginIp,
 		IssueToken: cryptos.MD5EncodeStringToHex(loginInfo.Token),
+		Timestamp:  timestamppb.New(loginTime),
 	}
-	if err := biz.authLogRepo.PublishUserLoginEvent(ctx, loginLog); err != nil {
-		log.Context(ctx).Errorf("Failed to publish user login event: %v", err)
+	// 登录事件经发件箱投递，与新用户写入同时提交或回滚
+	err = biz.tx.InTx(ctx, func(ctx context.Context) error {
+		if newUser {
+			if err := biz.authRepo.SetUserAuthInfo(ctx, userAuthInfo); err != nil {
+				return err
+			}
+		}
+		return biz.events.Publish(ctx, loginEvent)
+	})
+	if err != nil {
+		return nil, err
 	}
 	return loginInfo, nil
 }
@@ -250,16 +263,33 @@ func (biz *Auth) ValidateToken(ctx context.Context, tokenString string) (*webkit
 }
 
 func (biz *Auth) SaveUserLoginLog(ctx context.Context, userLoginLog *UserLoginLog) error {
+	return biz.authLogRepo.SaveUserLoginLog(ctx, biz.toLoginLogModel(ctx, userLoginLog))
+}
+
+// SaveUserLoginLogs 批量写入聚合后的登录日志
+func (biz *Auth) SaveUserLoginLogs(ctx context.Context, userLoginLogs []*UserLoginLog) error {
+	logs := make([]*model.UserLoginLog, 0, len(userLoginLogs))
+	for _, userLoginLog := range userLoginLogs {
+		logs = append(logs, biz.toLoginLogModel(ctx, userLoginLog))
+	}
+	return biz.authLogRepo.SaveUserLoginLogs(ctx, logs)
+}
+
+// toLoginLogModel 补充登录 IP 所属国家，查询失败时国家为空
+func (biz *Auth) toLoginLogModel(ctx context.Context, userLoginLog *UserLoginLog) *model.UserLoginLog {
+	var isoCode string
 	country, err := biz.geoIp.GetCountryFromIp(ctx, userLoginLog.LoginIp)
 	if err != nil {
 		log.Context(ctx).Errorf("get country by ip: %s error: %v", userLoginLog.LoginIp, err)
+	} else {
+		isoCode = country.IsoCode
 	}
-	return biz.authLogRepo.SaveUserLoginLog(ctx, &model.UserLoginLog{
+	return &model.UserLoginLog{
 		UserID:      userLoginLog.UserId,
 		AuthType:    userLoginLog.AuthType,
 		IssueToken:  userLoginLog.IssueToken,
 		IP:          userLoginLog.LoginIp,
-		Country:     country.IsoCode,
+		Country:     isoCode,
 		CreatedTime: userLoginLog.LoginTime,
-	})
+	}
 }
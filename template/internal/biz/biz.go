@@ -6,4 +6,5 @@ import "github.com/google/wire"
 var ProviderSet = wire.NewSet(
 	NewProbe,
 	NewAuth,
+	NewInterceptRule,
 )
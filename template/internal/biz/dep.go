@@ -7,6 +7,11 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// ITransaction 在同一数据库事务中执行多个 repo 操作
+type ITransaction interface {
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type IAlarmRepo interface {
 	SendBizMessage(ctx context.Context, title, info string)
 	SendMessage(ctx context.Context, platform, title, info string)
@@ -13,3 +13,21 @@ var (
 
 	ErrUserNotFound = web.ErrorUserNotFound("user not found")
 )
+
+var (
+	ErrInterceptRulesNotFound = web.ErrorInterceptRulesNotFound("intercept rules version not found")
+	ErrInterceptRulesConflict = web.ErrorInterceptRulesConflict("intercept rules have been changed, please reload the latest version")
+)
+
+func ErrInterceptRulesInvalid(err error) error {
+	return web.ErrorInterceptRulesInvalid("invalid intercept rules: %v", err)
+}
+
+var (
+	ErrTaskQueueNotFound = web.ErrorTaskQueueNotFound("task queue not found")
+	ErrTaskNotFound      = web.ErrorTaskNotFound("task not found")
+)
+
+func ErrTaskStateInvalid(err error) error {
+	return web.ErrorTaskStateInvalid("invalid task state: %v", err)
+}
@@ -0,0 +1,151 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/seanbit/kratos/template/api/event"
+	"github.com/seanbit/kratos/template/internal/data/model"
+	"github.com/seanbit/kratos/webkit"
+)
+
+const (
+	interceptRuleDefaultPageSize = 20
+)
+
+// IInterceptRuleRepo 拦截规则版本仓库（由data层实现）
+type IInterceptRuleRepo interface {
+	// ListVersions 按版本号倒序分页查询
+	ListVersions(ctx context.Context, serverName string, offset, limit int) ([]*model.InterceptRuleVersion, int64, error)
+	// GetVersion 查询指定版本，不存在时返回 nil
+	GetVersion(ctx context.Context, serverName string, version int64) (*model.InterceptRuleVersion, error)
+	// GetLatestVersion 查询最新版本，不存在时返回 nil
+	GetLatestVersion(ctx context.Context, serverName string) (*model.InterceptRuleVersion, error)
+	// CreateVersion 保存新版本，版本号已被占用时返回 ErrInterceptRulesConflict
+	CreateVersion(ctx context.Context, record *model.InterceptRuleVersion) error
+	// PublishConfig 发布规则到拦截器，重复发布同一配置没有副作用
+	PublishConfig(ctx context.Context, serverName string, conf *webkit.InterceptConfig) error
+}
+
+// InterceptRule 流量拦截规则管理
+// 规则以版本追加的方式保存，任何变更（包括回滚）都生成新版本，最新版本即为线上生效的规则
+// 新版本与变更事件同时提交，由事件处理发布最新版本，发布失败时随事件重试
+type InterceptRule struct {
+	tx     ITransaction
+	repo   IInterceptRuleRepo
+	events webkit.EventPublisher
+}
+
+func NewInterceptRule(tx ITransaction, repo IInterceptRuleRepo, events webkit.EventPublisher) *InterceptRule {
+	return &InterceptRule{tx: tx, repo: repo, events: events}
+}
+
+// ValidateConfig 严格校验规则 JSON，返回解析后的配置与规范化后的 JSON
+func (biz *InterceptRule) ValidateConfig(config string) (*webkit.InterceptConfig, string, error) {
+	conf, err := webkit.ParseInterceptConfig([]byte(config))
+	if err != nil {
+		return nil, "", ErrInterceptRulesInvalid(err)
+	}
+	data, err := json.Marshal(conf)
+	if err != nil {
+		return nil, "", ErrInterceptRulesInvalid(err)
+	}
+	return conf, string(data), nil
+}
+
+func (biz *InterceptRule) ListVersions(ctx context.Context, serverName string, page, pageSize int) ([]*model.InterceptRuleVersion, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = interceptRuleDefaultPageSize
+	}
+	return biz.repo.ListVersions(ctx, serverName, (page-1)*pageSize, pageSize)
+}
+
+// Create 创建首个版本，已有版本时应使用 Update
+func (biz *InterceptRule) Create(ctx context.Context, serverName, config, comment, author string) (*model.InterceptRuleVersion, error) {
+	latest, err := biz.repo.GetLatestVersion(ctx, serverName)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil {
+		return nil, ErrInterceptRulesConflict
+	}
+	return biz.publish(ctx, serverName, 1, config, comment, author, 0)
+}
+
+// Update 基于 baseVersion 修改规则，baseVersion 不是最新版本时返回冲突，避免覆盖他人的修改
+func (biz *InterceptRule) Update(ctx context.Context, serverName string, baseVersion int64, config, comment, author string) (*model.InterceptRuleVersion, error) {
+	latest, err := biz.repo.GetLatestVersion(ctx, serverName)
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil {
+		return nil, ErrInterceptRulesNotFound
+	}
+	if latest.Version != baseVersion {
+		return nil, ErrInterceptRulesConflict
+	}
+	return biz.publish(ctx, serverName, latest.Version+1, config, comment, author, 0)
+}
+
+// Rollback 以历史版本的规则生成新版本并发布，历史版本同样需要通过当前的校验
+func (biz *InterceptRule) Rollback(ctx context.Context, serverName string, version int64, comment, author string) (*model.InterceptRuleVersion, error) {
+	target, err := biz.repo.GetVersion(ctx, serverName, version)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, ErrInterceptRulesNotFound
+	}
+	latest, err := biz.repo.GetLatestVersion(ctx, serverName)
+	if err != nil {
+		return nil, err
+	}
+	return biz.publish(ctx, serverName, latest.Version+1, target.Config, comment, author, target.Version)
+}
+
+func (biz *InterceptRule) publish(ctx context.Context, serverName string, version int64, config, comment, author string, rollbackFrom int64) (*model.InterceptRuleVersion, error) {
+	_, normalized, err := biz.ValidateConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	record := &model.InterceptRuleVersion{
+		ServerName:   serverName,
+		Version:      version,
+		Config:       normalized,
+		Author:       author,
+		Comment:      comment,
+		RollbackFrom: rollbackFrom,
+		CreatedTime:  time.Now(),
+	}
+	// 变更事件经发件箱投递，与新版本同时提交或回滚
+	err = biz.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := biz.repo.CreateVersion(ctx, record); err != nil {
+			return err
+		}
+		return biz.events.Publish(ctx, &event.InterceptRulesChanged{ServerName: serverName, Version: version})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// PublishLatest 发布库中的最新版本，事件重复或乱序投递时结果相同
+func (biz *InterceptRule) PublishLatest(ctx context.Context, serverName string) error {
+	latest, err := biz.repo.GetLatestVersion(ctx, serverName)
+	if err != nil {
+		return err
+	}
+	if latest == nil {
+		return ErrInterceptRulesNotFound
+	}
+	conf, _, err := biz.ValidateConfig(latest.Config)
+	if err != nil {
+		return err
+	}
+	return biz.repo.PublishConfig(ctx, serverName, conf)
+}
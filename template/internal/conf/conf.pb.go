@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -150,6 +151,7 @@ type Bootstrap struct {
 	Auth          *Auth                  `protobuf:"bytes,9,opt,name=auth,proto3" json:"auth,omitempty"`
 	S3            *S3                    `protobuf:"bytes,10,opt,name=s3,proto3" json:"s3,omitempty"`
 	GeoIp         *GeoIp                 `protobuf:"bytes,11,opt,name=geo_ip,json=geoIp,proto3" json:"geo_ip,omitempty"`
+	Metrics       *Metrics               `protobuf:"bytes,12,opt,name=metrics,proto3" json:"metrics,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -261,11 +263,22 @@ func (x *Bootstrap) GetGeoIp() *GeoIp {
 	return nil
 }
 
+func (x *Bootstrap) GetMetrics() *Metrics {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
 type Server struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Http          *Server_HTTP           `protobuf:"bytes,1,opt,name=http,proto3" json:"http,omitempty"`
 	Grpc          *Server_GRPC           `protobuf:"bytes,2,opt,name=grpc,proto3" json:"grpc,omitempty"`
 	Asynq         *Server_ASYNQ          `protobuf:"bytes,3,opt,name=asynq,proto3" json:"asynq,omitempty"`
+	Intercept     *Server_Intercept      `protobuf:"bytes,4,opt,name=intercept,proto3" json:"intercept,omitempty"`
+	RateLimit     *Server_RateLimit      `protobuf:"bytes,5,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`
+	LoadShed      *Server_LoadShed       `protobuf:"bytes,6,opt,name=load_shed,json=loadShed,proto3" json:"load_shed,omitempty"`
+	Outbox        *Server_Outbox         `protobuf:"bytes,7,opt,name=outbox,proto3" json:"outbox,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -321,6 +334,34 @@ func (x *Server) GetAsynq() *Server_ASYNQ {
 	return nil
 }
 
+func (x *Server) GetIntercept() *Server_Intercept {
+	if x != nil {
+		return x.Intercept
+	}
+	return nil
+}
+
+func (x *Server) GetRateLimit() *Server_RateLimit {
+	if x != nil {
+		return x.RateLimit
+	}
+	return nil
+}
+
+func (x *Server) GetLoadShed() *Server_LoadShed {
+	if x != nil {
+		return x.LoadShed
+	}
+	return nil
+}
+
+func (x *Server) GetOutbox() *Server_Outbox {
+	if x != nil {
+		return x.Outbox
+	}
+	return nil
+}
+
 type Data struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Database      *Data_Database         `protobuf:"bytes,1,opt,name=database,proto3" json:"database,omitempty"`
@@ -373,11 +414,19 @@ func (x *Data) GetRedis() *Data_Redis {
 	return nil
 }
 
+// 链路追踪配置，未配置 type 时仅设置 W3C propagator
 type Tracing struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
-	Port          int32                  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
-	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 已废弃，使用 endpoint；配置时等价于 endpoint: host:port 且 insecure
+	Host          string                  `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Port          int32                   `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	Type          string                  `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`         // otlp_grpc, otlp_http, stdout
+	Endpoint      string                  `protobuf:"bytes,4,opt,name=endpoint,proto3" json:"endpoint,omitempty"` // host:port 或完整 URL
+	Insecure      bool                    `protobuf:"varint,5,opt,name=insecure,proto3" json:"insecure,omitempty"`
+	CaFile        string                  `protobuf:"bytes,6,opt,name=ca_file,json=caFile,proto3" json:"ca_file,omitempty"` // 自签 CA 证书路径
+	Headers       map[string]string       `protobuf:"bytes,7,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Sampler       string                  `protobuf:"bytes,8,opt,name=sampler,proto3" json:"sampler,omitempty"`                            // parent_ratio(默认), ratio, always_on, always_off
+	SampleRatio   *wrapperspb.DoubleValue `protobuf:"bytes,9,opt,name=sample_ratio,json=sampleRatio,proto3" json:"sample_ratio,omitempty"` // 默认 1.0
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -433,6 +482,133 @@ func (x *Tracing) GetType() string {
 	return ""
 }
 
+func (x *Tracing) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *Tracing) GetInsecure() bool {
+	if x != nil {
+		return x.Insecure
+	}
+	return false
+}
+
+func (x *Tracing) GetCaFile() string {
+	if x != nil {
+		return x.CaFile
+	}
+	return ""
+}
+
+func (x *Tracing) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *Tracing) GetSampler() string {
+	if x != nil {
+		return x.Sampler
+	}
+	return ""
+}
+
+func (x *Tracing) GetSampleRatio() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.SampleRatio
+	}
+	return nil
+}
+
+// 指标导出配置，未配置时仅注册 Prometheus
+type Metrics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Exporters     []string               `protobuf:"bytes,1,rep,name=exporters,proto3" json:"exporters,omitempty"`                           // prometheus, otlp_grpc, otlp_http
+	OtlpEndpoint  string                 `protobuf:"bytes,2,opt,name=otlp_endpoint,json=otlpEndpoint,proto3" json:"otlp_endpoint,omitempty"` // host:port or url
+	OtlpInsecure  bool                   `protobuf:"varint,3,opt,name=otlp_insecure,json=otlpInsecure,proto3" json:"otlp_insecure,omitempty"`
+	OtlpHeaders   map[string]string      `protobuf:"bytes,4,rep,name=otlp_headers,json=otlpHeaders,proto3" json:"otlp_headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	PushInterval  *durationpb.Duration   `protobuf:"bytes,5,opt,name=push_interval,json=pushInterval,proto3" json:"push_interval,omitempty"`
+	Histograms    []*Metrics_Histogram   `protobuf:"bytes,6,rep,name=histograms,proto3" json:"histograms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Metrics) Reset() {
+	*x = Metrics{}
+	mi := &file_conf_conf_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Metrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metrics) ProtoMessage() {}
+
+func (x *Metrics) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metrics.ProtoReflect.Descriptor instead.
+func (*Metrics) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Metrics) GetExporters() []string {
+	if x != nil {
+		return x.Exporters
+	}
+	return nil
+}
+
+func (x *Metrics) GetOtlpEndpoint() string {
+	if x != nil {
+		return x.OtlpEndpoint
+	}
+	return ""
+}
+
+func (x *Metrics) GetOtlpInsecure() bool {
+	if x != nil {
+		return x.OtlpInsecure
+	}
+	return false
+}
+
+func (x *Metrics) GetOtlpHeaders() map[string]string {
+	if x != nil {
+		return x.OtlpHeaders
+	}
+	return nil
+}
+
+func (x *Metrics) GetPushInterval() *durationpb.Duration {
+	if x != nil {
+		return x.PushInterval
+	}
+	return nil
+}
+
+func (x *Metrics) GetHistograms() []*Metrics_Histogram {
+	if x != nil {
+		return x.Histograms
+	}
+	return nil
+}
+
 type Sentry struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	Dsn              string                 `protobuf:"bytes,1,opt,name=dsn,proto3" json:"dsn,omitempty"`
@@ -443,7 +619,7 @@ type Sentry struct {
 
 func (x *Sentry) Reset() {
 	*x = Sentry{}
-	mi := &file_conf_conf_proto_msgTypes[4]
+	mi := &file_conf_conf_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -455,7 +631,7 @@ func (x *Sentry) String() string {
 func (*Sentry) ProtoMessage() {}
 
 func (x *Sentry) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[4]
+	mi := &file_conf_conf_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -468,7 +644,7 @@ func (x *Sentry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Sentry.ProtoReflect.Descriptor instead.
 func (*Sentry) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{4}
+	return file_conf_conf_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Sentry) GetDsn() string {
@@ -499,7 +675,7 @@ type Alarm struct {
 
 func (x *Alarm) Reset() {
 	*x = Alarm{}
-	mi := &file_conf_conf_proto_msgTypes[5]
+	mi := &file_conf_conf_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -511,7 +687,7 @@ func (x *Alarm) String() string {
 func (*Alarm) ProtoMessage() {}
 
 func (x *Alarm) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[5]
+	mi := &file_conf_conf_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -524,7 +700,7 @@ func (x *Alarm) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Alarm.ProtoReflect.Descriptor instead.
 func (*Alarm) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{5}
+	return file_conf_conf_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Alarm) GetWebHooks() map[string]string {
@@ -579,7 +755,7 @@ type Auth struct {
 
 func (x *Auth) Reset() {
 	*x = Auth{}
-	mi := &file_conf_conf_proto_msgTypes[6]
+	mi := &file_conf_conf_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -591,7 +767,7 @@ func (x *Auth) String() string {
 func (*Auth) ProtoMessage() {}
 
 func (x *Auth) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[6]
+	mi := &file_conf_conf_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -604,7 +780,7 @@ func (x *Auth) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Auth.ProtoReflect.Descriptor instead.
 func (*Auth) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{6}
+	return file_conf_conf_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *Auth) GetJwtKey_25519() string {
@@ -634,7 +810,7 @@ type Cos struct {
 
 func (x *Cos) Reset() {
 	*x = Cos{}
-	mi := &file_conf_conf_proto_msgTypes[7]
+	mi := &file_conf_conf_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -646,7 +822,7 @@ func (x *Cos) String() string {
 func (*Cos) ProtoMessage() {}
 
 func (x *Cos) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[7]
+	mi := &file_conf_conf_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -659,7 +835,7 @@ func (x *Cos) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Cos.ProtoReflect.Descriptor instead.
 func (*Cos) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{7}
+	return file_conf_conf_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *Cos) GetSecretId() string {
@@ -710,7 +886,7 @@ type S3 struct {
 
 func (x *S3) Reset() {
 	*x = S3{}
-	mi := &file_conf_conf_proto_msgTypes[8]
+	mi := &file_conf_conf_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -722,7 +898,7 @@ func (x *S3) String() string {
 func (*S3) ProtoMessage() {}
 
 func (x *S3) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[8]
+	mi := &file_conf_conf_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -735,7 +911,7 @@ func (x *S3) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use S3.ProtoReflect.Descriptor instead.
 func (*S3) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{8}
+	return file_conf_conf_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *S3) GetAccessKey() string {
@@ -783,7 +959,7 @@ type GeoIp struct {
 
 func (x *GeoIp) Reset() {
 	*x = GeoIp{}
-	mi := &file_conf_conf_proto_msgTypes[9]
+	mi := &file_conf_conf_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -795,7 +971,7 @@ func (x *GeoIp) String() string {
 func (*GeoIp) ProtoMessage() {}
 
 func (x *GeoIp) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[9]
+	mi := &file_conf_conf_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -808,7 +984,7 @@ func (x *GeoIp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GeoIp.ProtoReflect.Descriptor instead.
 func (*GeoIp) Descriptor() ([]byte, []int) {
-	return file_conf_conf_proto_rawDescGZIP(), []int{9}
+	return file_conf_conf_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GeoIp) GetFileBucket() string {
@@ -836,7 +1012,7 @@ type Server_HTTP struct {
 
 func (x *Server_HTTP) Reset() {
 	*x = Server_HTTP{}
-	mi := &file_conf_conf_proto_msgTypes[10]
+	mi := &file_conf_conf_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -848,7 +1024,7 @@ func (x *Server_HTTP) String() string {
 func (*Server_HTTP) ProtoMessage() {}
 
 func (x *Server_HTTP) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[10]
+	mi := &file_conf_conf_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -896,7 +1072,7 @@ type Server_GRPC struct {
 
 func (x *Server_GRPC) Reset() {
 	*x = Server_GRPC{}
-	mi := &file_conf_conf_proto_msgTypes[11]
+	mi := &file_conf_conf_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -908,7 +1084,7 @@ func (x *Server_GRPC) String() string {
 func (*Server_GRPC) ProtoMessage() {}
 
 func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[11]
+	mi := &file_conf_conf_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -946,17 +1122,22 @@ func (x *Server_GRPC) GetTimeout() *durationpb.Duration {
 }
 
 type Server_ASYNQ struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RedisUri      string                 `protobuf:"bytes,1,opt,name=redis_uri,json=redisUri,proto3" json:"redis_uri,omitempty"`
-	Queues        map[string]int32       `protobuf:"bytes,2,rep,name=queues,proto3" json:"queues,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
-	Concurrency   int32                  `protobuf:"varint,3,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	RedisUri    string                 `protobuf:"bytes,1,opt,name=redis_uri,json=redisUri,proto3" json:"redis_uri,omitempty"`
+	Queues      map[string]int32       `protobuf:"bytes,2,rep,name=queues,proto3" json:"queues,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Concurrency int32                  `protobuf:"varint,3,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	// 任务组聚合，如登录日志批量写入
+	GroupGracePeriod *durationpb.Duration `protobuf:"bytes,4,opt,name=group_grace_period,json=groupGracePeriod,proto3" json:"group_grace_period,omitempty"` // 默认 1m
+	GroupMaxDelay    *durationpb.Duration `protobuf:"bytes,5,opt,name=group_max_delay,json=groupMaxDelay,proto3" json:"group_max_delay,omitempty"`
+	GroupMaxSize     int32                `protobuf:"varint,6,opt,name=group_max_size,json=groupMaxSize,proto3" json:"group_max_size,omitempty"`
+	AdminUsers       []string             `protobuf:"bytes,7,rep,name=admin_users,json=adminUsers,proto3" json:"admin_users,omitempty"` // user ids allowed to manage tasks over http
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *Server_ASYNQ) Reset() {
 	*x = Server_ASYNQ{}
-	mi := &file_conf_conf_proto_msgTypes[12]
+	mi := &file_conf_conf_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -968,7 +1149,7 @@ func (x *Server_ASYNQ) String() string {
 func (*Server_ASYNQ) ProtoMessage() {}
 
 func (x *Server_ASYNQ) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[12]
+	mi := &file_conf_conf_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1005,6 +1186,462 @@ func (x *Server_ASYNQ) GetConcurrency() int32 {
 	return 0
 }
 
+func (x *Server_ASYNQ) GetGroupGracePeriod() *durationpb.Duration {
+	if x != nil {
+		return x.GroupGracePeriod
+	}
+	return nil
+}
+
+func (x *Server_ASYNQ) GetGroupMaxDelay() *durationpb.Duration {
+	if x != nil {
+		return x.GroupMaxDelay
+	}
+	return nil
+}
+
+func (x *Server_ASYNQ) GetGroupMaxSize() int32 {
+	if x != nil {
+		return x.GroupMaxSize
+	}
+	return 0
+}
+
+func (x *Server_ASYNQ) GetAdminUsers() []string {
+	if x != nil {
+		return x.AdminUsers
+	}
+	return nil
+}
+
+// 流量拦截签名配置
+type Server_Intercept struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SignEnabled     bool                   `protobuf:"varint,1,opt,name=sign_enabled,json=signEnabled,proto3" json:"sign_enabled,omitempty"`
+	SignSecret      string                 `protobuf:"bytes,2,opt,name=sign_secret,json=signSecret,proto3" json:"sign_secret,omitempty"`
+	SignatureLength int32                  `protobuf:"varint,3,opt,name=signature_length,json=signatureLength,proto3" json:"signature_length,omitempty"`
+	MaxTimeDrift    int64                  `protobuf:"varint,4,opt,name=max_time_drift,json=maxTimeDrift,proto3" json:"max_time_drift,omitempty"` // unit: second
+	RejectV1        bool                   `protobuf:"varint,5,opt,name=reject_v1,json=rejectV1,proto3" json:"reject_v1,omitempty"`
+	AdminUsers      []string               `protobuf:"bytes,6,rep,name=admin_users,json=adminUsers,proto3" json:"admin_users,omitempty"` // user ids allowed to manage intercept rules over http and grpc
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Server_Intercept) Reset() {
+	*x = Server_Intercept{}
+	mi := &file_conf_conf_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Intercept) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Intercept) ProtoMessage() {}
+
+func (x *Server_Intercept) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Intercept.ProtoReflect.Descriptor instead.
+func (*Server_Intercept) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 3}
+}
+
+func (x *Server_Intercept) GetSignEnabled() bool {
+	if x != nil {
+		return x.SignEnabled
+	}
+	return false
+}
+
+func (x *Server_Intercept) GetSignSecret() string {
+	if x != nil {
+		return x.SignSecret
+	}
+	return ""
+}
+
+func (x *Server_Intercept) GetSignatureLength() int32 {
+	if x != nil {
+		return x.SignatureLength
+	}
+	return 0
+}
+
+func (x *Server_Intercept) GetMaxTimeDrift() int64 {
+	if x != nil {
+		return x.MaxTimeDrift
+	}
+	return 0
+}
+
+func (x *Server_Intercept) GetRejectV1() bool {
+	if x != nil {
+		return x.RejectV1
+	}
+	return false
+}
+
+func (x *Server_Intercept) GetAdminUsers() []string {
+	if x != nil {
+		return x.AdminUsers
+	}
+	return nil
+}
+
+// 分布式限流配置，请求需同时满足所有命中规则的配额
+type Server_RateLimit struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Rules         []*Server_RateLimit_Rule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	FailClosed    bool                     `protobuf:"varint,2,opt,name=fail_closed,json=failClosed,proto3" json:"fail_closed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_RateLimit) Reset() {
+	*x = Server_RateLimit{}
+	mi := &file_conf_conf_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_RateLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_RateLimit) ProtoMessage() {}
+
+func (x *Server_RateLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_RateLimit.ProtoReflect.Descriptor instead.
+func (*Server_RateLimit) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 4}
+}
+
+func (x *Server_RateLimit) GetRules() []*Server_RateLimit_Rule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *Server_RateLimit) GetFailClosed() bool {
+	if x != nil {
+		return x.FailClosed
+	}
+	return false
+}
+
+// 过载保护配置，未配置的接口按 rpc 的 (web.priority) 选项确定优先级
+type Server_LoadShed struct {
+	state           protoimpl.MessageState  `protogen:"open.v1"`
+	Rules           []*Server_LoadShed_Rule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	DefaultPriority string                  `protobuf:"bytes,2,opt,name=default_priority,json=defaultPriority,proto3" json:"default_priority,omitempty"`
+	InitialLimit    int32                   `protobuf:"varint,3,opt,name=initial_limit,json=initialLimit,proto3" json:"initial_limit,omitempty"`
+	MinLimit        int32                   `protobuf:"varint,4,opt,name=min_limit,json=minLimit,proto3" json:"min_limit,omitempty"`
+	MaxLimit        int32                   `protobuf:"varint,5,opt,name=max_limit,json=maxLimit,proto3" json:"max_limit,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Server_LoadShed) Reset() {
+	*x = Server_LoadShed{}
+	mi := &file_conf_conf_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_LoadShed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_LoadShed) ProtoMessage() {}
+
+func (x *Server_LoadShed) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_LoadShed.ProtoReflect.Descriptor instead.
+func (*Server_LoadShed) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 5}
+}
+
+func (x *Server_LoadShed) GetRules() []*Server_LoadShed_Rule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *Server_LoadShed) GetDefaultPriority() string {
+	if x != nil {
+		return x.DefaultPriority
+	}
+	return ""
+}
+
+func (x *Server_LoadShed) GetInitialLimit() int32 {
+	if x != nil {
+		return x.InitialLimit
+	}
+	return 0
+}
+
+func (x *Server_LoadShed) GetMinLimit() int32 {
+	if x != nil {
+		return x.MinLimit
+	}
+	return 0
+}
+
+func (x *Server_LoadShed) GetMaxLimit() int32 {
+	if x != nil {
+		return x.MaxLimit
+	}
+	return 0
+}
+
+// 事务发件箱投递配置
+type Server_Outbox struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Interval      *durationpb.Duration   `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`                     // 默认 1s
+	BatchSize     int32                  `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"` // 默认 100
+	Retention     *durationpb.Duration   `protobuf:"bytes,3,opt,name=retention,proto3" json:"retention,omitempty"`                   // 已发布消息保留时间，默认 7 天
+	MaxRetry      int32                  `protobuf:"varint,4,opt,name=max_retry,json=maxRetry,proto3" json:"max_retry,omitempty"`    // 投递到 asynq 的任务重试次数
+	TaskTimeout   *durationpb.Duration   `protobuf:"bytes,5,opt,name=task_timeout,json=taskTimeout,proto3" json:"task_timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_Outbox) Reset() {
+	*x = Server_Outbox{}
+	mi := &file_conf_conf_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Outbox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Outbox) ProtoMessage() {}
+
+func (x *Server_Outbox) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Outbox.ProtoReflect.Descriptor instead.
+func (*Server_Outbox) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 6}
+}
+
+func (x *Server_Outbox) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *Server_Outbox) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *Server_Outbox) GetRetention() *durationpb.Duration {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
+func (x *Server_Outbox) GetMaxRetry() int32 {
+	if x != nil {
+		return x.MaxRetry
+	}
+	return 0
+}
+
+func (x *Server_Outbox) GetTaskTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.TaskTimeout
+	}
+	return nil
+}
+
+type Server_RateLimit_Rule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Operations    []string               `protobuf:"bytes,2,rep,name=operations,proto3" json:"operations,omitempty"` // exact operation, or prefix ending with *
+	Keys          []string               `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty"`             // user, ip, operation, header:{name}
+	Rate          int32                  `protobuf:"varint,4,opt,name=rate,proto3" json:"rate,omitempty"`
+	Period        *durationpb.Duration   `protobuf:"bytes,5,opt,name=period,proto3" json:"period,omitempty"` // 整数秒，默认 1s
+	Burst         int32                  `protobuf:"varint,6,opt,name=burst,proto3" json:"burst,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_RateLimit_Rule) Reset() {
+	*x = Server_RateLimit_Rule{}
+	mi := &file_conf_conf_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_RateLimit_Rule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_RateLimit_Rule) ProtoMessage() {}
+
+func (x *Server_RateLimit_Rule) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_RateLimit_Rule.ProtoReflect.Descriptor instead.
+func (*Server_RateLimit_Rule) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 4, 0}
+}
+
+func (x *Server_RateLimit_Rule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Server_RateLimit_Rule) GetOperations() []string {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *Server_RateLimit_Rule) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *Server_RateLimit_Rule) GetRate() int32 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *Server_RateLimit_Rule) GetPeriod() *durationpb.Duration {
+	if x != nil {
+		return x.Period
+	}
+	return nil
+}
+
+func (x *Server_RateLimit_Rule) GetBurst() int32 {
+	if x != nil {
+		return x.Burst
+	}
+	return 0
+}
+
+type Server_LoadShed_Rule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operations    []string               `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"` // exact operation, or prefix ending with *
+	Priority      string                 `protobuf:"bytes,2,opt,name=priority,proto3" json:"priority,omitempty"`     // low, normal, high, critical, exempt
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_LoadShed_Rule) Reset() {
+	*x = Server_LoadShed_Rule{}
+	mi := &file_conf_conf_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_LoadShed_Rule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_LoadShed_Rule) ProtoMessage() {}
+
+func (x *Server_LoadShed_Rule) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_LoadShed_Rule.ProtoReflect.Descriptor instead.
+func (*Server_LoadShed_Rule) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 5, 0}
+}
+
+func (x *Server_LoadShed_Rule) GetOperations() []string {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *Server_LoadShed_Rule) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
 type Data_Database struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
 	Driver             string                 `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
@@ -1023,7 +1660,7 @@ type Data_Database struct {
 
 func (x *Data_Database) Reset() {
 	*x = Data_Database{}
-	mi := &file_conf_conf_proto_msgTypes[14]
+	mi := &file_conf_conf_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1035,7 +1672,7 @@ func (x *Data_Database) String() string {
 func (*Data_Database) ProtoMessage() {}
 
 func (x *Data_Database) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[14]
+	mi := &file_conf_conf_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1140,7 +1777,7 @@ type Data_Redis struct {
 
 func (x *Data_Redis) Reset() {
 	*x = Data_Redis{}
-	mi := &file_conf_conf_proto_msgTypes[15]
+	mi := &file_conf_conf_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1152,7 +1789,7 @@ func (x *Data_Redis) String() string {
 func (*Data_Redis) ProtoMessage() {}
 
 func (x *Data_Redis) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[15]
+	mi := &file_conf_conf_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1245,12 +1882,64 @@ func (x *Data_Redis) GetIdleTimeout() *durationpb.Duration {
 	return nil
 }
 
+type Metrics_Histogram struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Buckets       []float64              `protobuf:"fixed64,2,rep,packed,name=buckets,proto3" json:"buckets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Metrics_Histogram) Reset() {
+	*x = Metrics_Histogram{}
+	mi := &file_conf_conf_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Metrics_Histogram) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metrics_Histogram) ProtoMessage() {}
+
+func (x *Metrics_Histogram) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metrics_Histogram.ProtoReflect.Descriptor instead.
+func (*Metrics_Histogram) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{4, 0}
+}
+
+func (x *Metrics_Histogram) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Metrics_Histogram) GetBuckets() []float64 {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
 var File_conf_conf_proto protoreflect.FileDescriptor
 
 const file_conf_conf_proto_rawDesc = "" +
 	"\n" +
 	"\x0fconf/conf.proto\x12\n" +
-	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\"\xbb\x03\n" +
+	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\x1a\x1egoogle/protobuf/wrappers.proto\"\xea\x03\n" +
 	"\tBootstrap\x12*\n" +
 	"\x06server\x18\x01 \x01(\v2\x12.kratos.api.ServerR\x06server\x12$\n" +
 	"\x04data\x18\x02 \x01(\v2\x10.kratos.api.DataR\x04data\x12!\n" +
@@ -1263,11 +1952,17 @@ const file_conf_conf_proto_rawDesc = "" +
 	"\x04auth\x18\t \x01(\v2\x10.kratos.api.AuthR\x04auth\x12\x1e\n" +
 	"\x02s3\x18\n" +
 	" \x01(\v2\x0e.kratos.api.S3R\x02s3\x12(\n" +
-	"\x06geo_ip\x18\v \x01(\v2\x11.kratos.api.GeoIpR\x05geoIp\"\xaa\x04\n" +
+	"\x06geo_ip\x18\v \x01(\v2\x11.kratos.api.GeoIpR\x05geoIp\x12-\n" +
+	"\ametrics\x18\f \x01(\v2\x13.kratos.api.MetricsR\ametrics\"\xe2\x0f\n" +
 	"\x06Server\x12+\n" +
 	"\x04http\x18\x01 \x01(\v2\x17.kratos.api.Server.HTTPR\x04http\x12+\n" +
 	"\x04grpc\x18\x02 \x01(\v2\x17.kratos.api.Server.GRPCR\x04grpc\x12.\n" +
-	"\x05asynq\x18\x03 \x01(\v2\x18.kratos.api.Server.ASYNQR\x05asynq\x1ai\n" +
+	"\x05asynq\x18\x03 \x01(\v2\x18.kratos.api.Server.ASYNQR\x05asynq\x12:\n" +
+	"\tintercept\x18\x04 \x01(\v2\x1c.kratos.api.Server.InterceptR\tintercept\x12;\n" +
+	"\n" +
+	"rate_limit\x18\x05 \x01(\v2\x1c.kratos.api.Server.RateLimitR\trateLimit\x128\n" +
+	"\tload_shed\x18\x06 \x01(\v2\x1b.kratos.api.Server.LoadShedR\bloadShed\x121\n" +
+	"\x06outbox\x18\a \x01(\v2\x19.kratos.api.Server.OutboxR\x06outbox\x1ai\n" +
 	"\x04HTTP\x12\x18\n" +
 	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
 	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
@@ -1275,14 +1970,59 @@ const file_conf_conf_proto_rawDesc = "" +
 	"\x04GRPC\x12\x18\n" +
 	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
 	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
-	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x1a\xbf\x01\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x1a\x92\x03\n" +
 	"\x05ASYNQ\x12\x1b\n" +
 	"\tredis_uri\x18\x01 \x01(\tR\bredisUri\x12<\n" +
 	"\x06queues\x18\x02 \x03(\v2$.kratos.api.Server.ASYNQ.QueuesEntryR\x06queues\x12 \n" +
-	"\vconcurrency\x18\x03 \x01(\x05R\vconcurrency\x1a9\n" +
+	"\vconcurrency\x18\x03 \x01(\x05R\vconcurrency\x12G\n" +
+	"\x12group_grace_period\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\x10groupGracePeriod\x12A\n" +
+	"\x0fgroup_max_delay\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\rgroupMaxDelay\x12$\n" +
+	"\x0egroup_max_size\x18\x06 \x01(\x05R\fgroupMaxSize\x12\x1f\n" +
+	"\vadmin_users\x18\a \x03(\tR\n" +
+	"adminUsers\x1a9\n" +
 	"\vQueuesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\xcd\a\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a\xde\x01\n" +
+	"\tIntercept\x12!\n" +
+	"\fsign_enabled\x18\x01 \x01(\bR\vsignEnabled\x12\x1f\n" +
+	"\vsign_secret\x18\x02 \x01(\tR\n" +
+	"signSecret\x12)\n" +
+	"\x10signature_length\x18\x03 \x01(\x05R\x0fsignatureLength\x12$\n" +
+	"\x0emax_time_drift\x18\x04 \x01(\x03R\fmaxTimeDrift\x12\x1b\n" +
+	"\treject_v1\x18\x05 \x01(\bR\brejectV1\x12\x1f\n" +
+	"\vadmin_users\x18\x06 \x03(\tR\n" +
+	"adminUsers\x1a\x93\x02\n" +
+	"\tRateLimit\x127\n" +
+	"\x05rules\x18\x01 \x03(\v2!.kratos.api.Server.RateLimit.RuleR\x05rules\x12\x1f\n" +
+	"\vfail_closed\x18\x02 \x01(\bR\n" +
+	"failClosed\x1a\xab\x01\n" +
+	"\x04Rule\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1e\n" +
+	"\n" +
+	"operations\x18\x02 \x03(\tR\n" +
+	"operations\x12\x12\n" +
+	"\x04keys\x18\x03 \x03(\tR\x04keys\x12\x12\n" +
+	"\x04rate\x18\x04 \x01(\x05R\x04rate\x121\n" +
+	"\x06period\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x06period\x12\x14\n" +
+	"\x05burst\x18\x06 \x01(\x05R\x05burst\x1a\x90\x02\n" +
+	"\bLoadShed\x126\n" +
+	"\x05rules\x18\x01 \x03(\v2 .kratos.api.Server.LoadShed.RuleR\x05rules\x12)\n" +
+	"\x10default_priority\x18\x02 \x01(\tR\x0fdefaultPriority\x12#\n" +
+	"\rinitial_limit\x18\x03 \x01(\x05R\finitialLimit\x12\x1b\n" +
+	"\tmin_limit\x18\x04 \x01(\x05R\bminLimit\x12\x1b\n" +
+	"\tmax_limit\x18\x05 \x01(\x05R\bmaxLimit\x1aB\n" +
+	"\x04Rule\x12\x1e\n" +
+	"\n" +
+	"operations\x18\x01 \x03(\tR\n" +
+	"operations\x12\x1a\n" +
+	"\bpriority\x18\x02 \x01(\tR\bpriority\x1a\xf2\x01\n" +
+	"\x06Outbox\x125\n" +
+	"\binterval\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x02 \x01(\x05R\tbatchSize\x127\n" +
+	"\tretention\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\tretention\x12\x1b\n" +
+	"\tmax_retry\x18\x04 \x01(\x05R\bmaxRetry\x12<\n" +
+	"\ftask_timeout\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\vtaskTimeout\"\xcd\a\n" +
 	"\x04Data\x125\n" +
 	"\bdatabase\x18\x01 \x01(\v2\x19.kratos.api.Data.DatabaseR\bdatabase\x12,\n" +
 	"\x05redis\x18\x02 \x01(\v2\x16.kratos.api.Data.RedisR\x05redis\x1a\xb5\x03\n" +
@@ -1313,11 +2053,35 @@ const file_conf_conf_proto_rawDesc = "" +
 	"\rmin_idle_conn\x18\t \x01(\rR\vminIdleConn\x12&\n" +
 	"\x0fmax_retry_times\x18\n" +
 	" \x01(\rR\rmaxRetryTimes\x12<\n" +
-	"\fidle_timeout\x18\v \x01(\v2\x19.google.protobuf.DurationR\vidleTimeout\"E\n" +
+	"\fidle_timeout\x18\v \x01(\v2\x19.google.protobuf.DurationR\vidleTimeout\"\xe9\x02\n" +
 	"\aTracing\x12\x12\n" +
 	"\x04host\x18\x01 \x01(\tR\x04host\x12\x12\n" +
 	"\x04port\x18\x02 \x01(\x05R\x04port\x12\x12\n" +
-	"\x04type\x18\x03 \x01(\tR\x04type\"H\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x1a\n" +
+	"\bendpoint\x18\x04 \x01(\tR\bendpoint\x12\x1a\n" +
+	"\binsecure\x18\x05 \x01(\bR\binsecure\x12\x17\n" +
+	"\aca_file\x18\x06 \x01(\tR\x06caFile\x12:\n" +
+	"\aheaders\x18\a \x03(\v2 .kratos.api.Tracing.HeadersEntryR\aheaders\x12\x18\n" +
+	"\asampler\x18\b \x01(\tR\asampler\x12?\n" +
+	"\fsample_ratio\x18\t \x01(\v2\x1c.google.protobuf.DoubleValueR\vsampleRatio\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb4\x03\n" +
+	"\aMetrics\x12\x1c\n" +
+	"\texporters\x18\x01 \x03(\tR\texporters\x12#\n" +
+	"\rotlp_endpoint\x18\x02 \x01(\tR\fotlpEndpoint\x12#\n" +
+	"\rotlp_insecure\x18\x03 \x01(\bR\fotlpInsecure\x12G\n" +
+	"\fotlp_headers\x18\x04 \x03(\v2$.kratos.api.Metrics.OtlpHeadersEntryR\votlpHeaders\x12>\n" +
+	"\rpush_interval\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\fpushInterval\x12=\n" +
+	"\n" +
+	"histograms\x18\x06 \x03(\v2\x1d.kratos.api.Metrics.HistogramR\n" +
+	"histograms\x1a9\n" +
+	"\tHistogram\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\abuckets\x18\x02 \x03(\x01R\abuckets\x1a>\n" +
+	"\x10OtlpHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"H\n" +
 	"\x06Sentry\x12\x10\n" +
 	"\x03dsn\x18\x01 \x01(\tR\x03dsn\x12,\n" +
 	"\x12attach_stack_trace\x18\x02 \x01(\bR\x10attachStackTrace\"\x82\x03\n" +
@@ -1379,62 +2143,91 @@ func file_conf_conf_proto_rawDescGZIP() []byte {
 }
 
 var file_conf_conf_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
 var file_conf_conf_proto_goTypes = []any{
-	(Env)(0),                    // 0: kratos.api.Env
-	(LogLevel)(0),               // 1: kratos.api.LogLevel
-	(*Bootstrap)(nil),           // 2: kratos.api.Bootstrap
-	(*Server)(nil),              // 3: kratos.api.Server
-	(*Data)(nil),                // 4: kratos.api.Data
-	(*Tracing)(nil),             // 5: kratos.api.Tracing
-	(*Sentry)(nil),              // 6: kratos.api.Sentry
-	(*Alarm)(nil),               // 7: kratos.api.Alarm
-	(*Auth)(nil),                // 8: kratos.api.Auth
-	(*Cos)(nil),                 // 9: kratos.api.Cos
-	(*S3)(nil),                  // 10: kratos.api.S3
-	(*GeoIp)(nil),               // 11: kratos.api.GeoIp
-	(*Server_HTTP)(nil),         // 12: kratos.api.Server.HTTP
-	(*Server_GRPC)(nil),         // 13: kratos.api.Server.GRPC
-	(*Server_ASYNQ)(nil),        // 14: kratos.api.Server.ASYNQ
-	nil,                         // 15: kratos.api.Server.ASYNQ.QueuesEntry
-	(*Data_Database)(nil),       // 16: kratos.api.Data.Database
-	(*Data_Redis)(nil),          // 17: kratos.api.Data.Redis
-	nil,                         // 18: kratos.api.Alarm.WebHooksEntry
-	(*durationpb.Duration)(nil), // 19: google.protobuf.Duration
+	(Env)(0),                       // 0: kratos.api.Env
+	(LogLevel)(0),                  // 1: kratos.api.LogLevel
+	(*Bootstrap)(nil),              // 2: kratos.api.Bootstrap
+	(*Server)(nil),                 // 3: kratos.api.Server
+	(*Data)(nil),                   // 4: kratos.api.Data
+	(*Tracing)(nil),                // 5: kratos.api.Tracing
+	(*Metrics)(nil),                // 6: kratos.api.Metrics
+	(*Sentry)(nil),                 // 7: kratos.api.Sentry
+	(*Alarm)(nil),                  // 8: kratos.api.Alarm
+	(*Auth)(nil),                   // 9: kratos.api.Auth
+	(*Cos)(nil),                    // 10: kratos.api.Cos
+	(*S3)(nil),                     // 11: kratos.api.S3
+	(*GeoIp)(nil),                  // 12: kratos.api.GeoIp
+	(*Server_HTTP)(nil),            // 13: kratos.api.Server.HTTP
+	(*Server_GRPC)(nil),            // 14: kratos.api.Server.GRPC
+	(*Server_ASYNQ)(nil),           // 15: kratos.api.Server.ASYNQ
+	(*Server_Intercept)(nil),       // 16: kratos.api.Server.Intercept
+	(*Server_RateLimit)(nil),       // 17: kratos.api.Server.RateLimit
+	(*Server_LoadShed)(nil),        // 18: kratos.api.Server.LoadShed
+	(*Server_Outbox)(nil),          // 19: kratos.api.Server.Outbox
+	nil,                            // 20: kratos.api.Server.ASYNQ.QueuesEntry
+	(*Server_RateLimit_Rule)(nil),  // 21: kratos.api.Server.RateLimit.Rule
+	(*Server_LoadShed_Rule)(nil),   // 22: kratos.api.Server.LoadShed.Rule
+	(*Data_Database)(nil),          // 23: kratos.api.Data.Database
+	(*Data_Redis)(nil),             // 24: kratos.api.Data.Redis
+	nil,                            // 25: kratos.api.Tracing.HeadersEntry
+	(*Metrics_Histogram)(nil),      // 26: kratos.api.Metrics.Histogram
+	nil,                            // 27: kratos.api.Metrics.OtlpHeadersEntry
+	nil,                            // 28: kratos.api.Alarm.WebHooksEntry
+	(*wrapperspb.DoubleValue)(nil), // 29: google.protobuf.DoubleValue
+	(*durationpb.Duration)(nil),    // 30: google.protobuf.Duration
 }
 var file_conf_conf_proto_depIdxs = []int32{
 	3,  // 0: kratos.api.Bootstrap.server:type_name -> kratos.api.Server
 	4,  // 1: kratos.api.Bootstrap.data:type_name -> kratos.api.Data
 	0,  // 2: kratos.api.Bootstrap.env:type_name -> kratos.api.Env
 	1,  // 3: kratos.api.Bootstrap.log_level:type_name -> kratos.api.LogLevel
-	6,  // 4: kratos.api.Bootstrap.sentry:type_name -> kratos.api.Sentry
+	7,  // 4: kratos.api.Bootstrap.sentry:type_name -> kratos.api.Sentry
 	5,  // 5: kratos.api.Bootstrap.tracing:type_name -> kratos.api.Tracing
-	7,  // 6: kratos.api.Bootstrap.alarm:type_name -> kratos.api.Alarm
-	8,  // 7: kratos.api.Bootstrap.auth:type_name -> kratos.api.Auth
-	10, // 8: kratos.api.Bootstrap.s3:type_name -> kratos.api.S3
-	11, // 9: kratos.api.Bootstrap.geo_ip:type_name -> kratos.api.GeoIp
-	12, // 10: kratos.api.Server.http:type_name -> kratos.api.Server.HTTP
-	13, // 11: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
-	14, // 12: kratos.api.Server.asynq:type_name -> kratos.api.Server.ASYNQ
-	16, // 13: kratos.api.Data.database:type_name -> kratos.api.Data.Database
-	17, // 14: kratos.api.Data.redis:type_name -> kratos.api.Data.Redis
-	18, // 15: kratos.api.Alarm.web_hooks:type_name -> kratos.api.Alarm.WebHooksEntry
-	19, // 16: kratos.api.Alarm.cache_ignore_duration:type_name -> google.protobuf.Duration
-	19, // 17: kratos.api.Alarm.cache_fuse_duration:type_name -> google.protobuf.Duration
-	19, // 18: kratos.api.Auth.login_expires:type_name -> google.protobuf.Duration
-	19, // 19: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
-	19, // 20: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
-	15, // 21: kratos.api.Server.ASYNQ.queues:type_name -> kratos.api.Server.ASYNQ.QueuesEntry
-	19, // 22: kratos.api.Data.Database.conn_max_lifetime:type_name -> google.protobuf.Duration
-	19, // 23: kratos.api.Data.Database.conn_max_idle_time:type_name -> google.protobuf.Duration
-	19, // 24: kratos.api.Data.Redis.read_timeout:type_name -> google.protobuf.Duration
-	19, // 25: kratos.api.Data.Redis.write_timeout:type_name -> google.protobuf.Duration
-	19, // 26: kratos.api.Data.Redis.idle_timeout:type_name -> google.protobuf.Duration
-	27, // [27:27] is the sub-list for method output_type
-	27, // [27:27] is the sub-list for method input_type
-	27, // [27:27] is the sub-list for extension type_name
-	27, // [27:27] is the sub-list for extension extendee
-	0,  // [0:27] is the sub-list for field type_name
+	8,  // 6: kratos.api.Bootstrap.alarm:type_name -> kratos.api.Alarm
+	9,  // 7: kratos.api.Bootstrap.auth:type_name -> kratos.api.Auth
+	11, // 8: kratos.api.Bootstrap.s3:type_name -> kratos.api.S3
+	12, // 9: kratos.api.Bootstrap.geo_ip:type_name -> kratos.api.GeoIp
+	6,  // 10: kratos.api.Bootstrap.metrics:type_name -> kratos.api.Metrics
+	13, // 11: kratos.api.Server.http:type_name -> kratos.api.Server.HTTP
+	14, // 12: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
+	15, // 13: kratos.api.Server.asynq:type_name -> kratos.api.Server.ASYNQ
+	16, // 14: kratos.api.Server.intercept:type_name -> kratos.api.Server.Intercept
+	17, // 15: kratos.api.Server.rate_limit:type_name -> kratos.api.Server.RateLimit
+	18, // 16: kratos.api.Server.load_shed:type_name -> kratos.api.Server.LoadShed
+	19, // 17: kratos.api.Server.outbox:type_name -> kratos.api.Server.Outbox
+	23, // 18: kratos.api.Data.database:type_name -> kratos.api.Data.Database
+	24, // 19: kratos.api.Data.redis:type_name -> kratos.api.Data.Redis
+	25, // 20: kratos.api.Tracing.headers:type_name -> kratos.api.Tracing.HeadersEntry
+	29, // 21: kratos.api.Tracing.sample_ratio:type_name -> google.protobuf.DoubleValue
+	27, // 22: kratos.api.Metrics.otlp_headers:type_name -> kratos.api.Metrics.OtlpHeadersEntry
+	30, // 23: kratos.api.Metrics.push_interval:type_name -> google.protobuf.Duration
+	26, // 24: kratos.api.Metrics.histograms:type_name -> kratos.api.Metrics.Histogram
+	28, // 25: kratos.api.Alarm.web_hooks:type_name -> kratos.api.Alarm.WebHooksEntry
+	30, // 26: kratos.api.Alarm.cache_ignore_duration:type_name -> google.protobuf.Duration
+	30, // 27: kratos.api.Alarm.cache_fuse_duration:type_name -> google.protobuf.Duration
+	30, // 28: kratos.api.Auth.login_expires:type_name -> google.protobuf.Duration
+	30, // 29: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
+	30, // 30: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
+	20, // 31: kratos.api.Server.ASYNQ.queues:type_name -> kratos.api.Server.ASYNQ.QueuesEntry
+	30, // 32: kratos.api.Server.ASYNQ.group_grace_period:type_name -> google.protobuf.Duration
+	30, // 33: kratos.api.Server.ASYNQ.group_max_delay:type_name -> google.protobuf.Duration
+	21, // 34: kratos.api.Server.RateLimit.rules:type_name -> kratos.api.Server.RateLimit.Rule
+	22, // 35: kratos.api.Server.LoadShed.rules:type_name -> kratos.api.Server.LoadShed.Rule
+	30, // 36: kratos.api.Server.Outbox.interval:type_name -> google.protobuf.Duration
+	30, // 37: kratos.api.Server.Outbox.retention:type_name -> google.protobuf.Duration
+	30, // 38: kratos.api.Server.Outbox.task_timeout:type_name -> google.protobuf.Duration
+	30, // 39: kratos.api.Server.RateLimit.Rule.period:type_name -> google.protobuf.Duration
+	30, // 40: kratos.api.Data.Database.conn_max_lifetime:type_name -> google.protobuf.Duration
+	30, // 41: kratos.api.Data.Database.conn_max_idle_time:type_name -> google.protobuf.Duration
+	30, // 42: kratos.api.Data.Redis.read_timeout:type_name -> google.protobuf.Duration
+	30, // 43: kratos.api.Data.Redis.write_timeout:type_name -> google.protobuf.Duration
+	30, // 44: kratos.api.Data.Redis.idle_timeout:type_name -> google.protobuf.Duration
+	45, // [45:45] is the sub-list for method output_type
+	45, // [45:45] is the sub-list for method input_type
+	45, // [45:45] is the sub-list for extension type_name
+	45, // [45:45] is the sub-list for extension extendee
+	0,  // [0:45] is the sub-list for field type_name
 }
 
 func init() { file_conf_conf_proto_init() }
@@ -1448,7 +2241,7 @@ func file_conf_conf_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_conf_conf_proto_rawDesc), len(file_conf_conf_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   17,
+			NumMessages:   27,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -9,6 +9,8 @@ import (
 var ProviderSet = wire.NewSet(
 	NewJobTest,
 	NewJobRegister,
+	NewPeriodicTest,
+	NewPeriodicTaskRegister,
 )
 
 func NewJobRegister(test *JobTest) crontab.JobRegister {
@@ -0,0 +1,48 @@
+package crontab
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	asynq2 "github.com/hibiken/asynq"
+	"github.com/seanbit/kratos/webkit/transport/asynq"
+)
+
+// TaskTypePeriodicTest 周期任务示例的任务类型
+const TaskTypePeriodicTest = "periodic:test"
+
+// PeriodicTest 由 asynq.Scheduler 投递、asynq.Server 消费的周期任务，失败时按队列策略重试
+type PeriodicTest struct {
+}
+
+func NewPeriodicTest() *PeriodicTest {
+	return &PeriodicTest{}
+}
+
+// Register 注册任务处理函数
+func (task *PeriodicTest) Register(r *asynq.Router) {
+	r.HandleFunc(TaskTypePeriodicTest, task.ProcessTask)
+}
+
+func (task *PeriodicTest) ProcessTask(ctx context.Context, _ *asynq2.Task) error {
+	log.Context(ctx).Debugf("periodic test run")
+	return nil
+}
+
+type PeriodicTaskRegister struct {
+	tasks []asynq.PeriodicTask
+}
+
+func NewPeriodicTaskRegister() asynq.PeriodicTaskRegister {
+	return &PeriodicTaskRegister{
+		tasks: []asynq.PeriodicTask{
+			// 多实例各自运行 Scheduler，Unique 保证同一周期只投递一次
+			asynq.NewPeriodicTask("*/5 * * * *", asynq2.NewTask(TaskTypePeriodicTest, nil), asynq2.Unique(time.Minute)),
+		},
+	}
+}
+
+func (register *PeriodicTaskRegister) PeriodicTasks() []asynq.PeriodicTask {
+	return register.tasks
+}
@@ -54,7 +54,7 @@ func (repo *authRepo) GetUserAuthInfoByAuthType(ctx context.Context, userId, aut
 }
 
 func (repo *authRepo) SetUserAuthInfo(ctx context.Context, userAuthInfo *model.UserAuthInfo) error {
-	userAuthInfoQ := dao.Use(repo.dbProvider.GetDB()).UserAuthInfo
+	userAuthInfoQ := dao.Use(getDB(ctx, repo.dbProvider)).UserAuthInfo
 	userAuthInfoDo := userAuthInfoQ.WithContext(ctx)
 	return userAuthInfoDo.Clauses(clause.OnConflict{
 		Columns: []clause.Column{
@@ -17,29 +17,32 @@ import (
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
-		db:              db,
-		AlarmFilterWord: newAlarmFilterWord(db, opts...),
-		UserAuthInfo:    newUserAuthInfo(db, opts...),
-		UserLoginLog:    newUserLoginLog(db, opts...),
+		db:                   db,
+		AlarmFilterWord:      newAlarmFilterWord(db, opts...),
+		InterceptRuleVersion: newInterceptRuleVersion(db, opts...),
+		UserAuthInfo:         newUserAuthInfo(db, opts...),
+		UserLoginLog:         newUserLoginLog(db, opts...),
 	}
 }
 
 type Query struct {
 	db *gorm.DB
 
-	AlarmFilterWord alarmFilterWord
-	UserAuthInfo    userAuthInfo
-	UserLoginLog    userLoginLog
+	AlarmFilterWord      alarmFilterWord
+	InterceptRuleVersion interceptRuleVersion
+	UserAuthInfo         userAuthInfo
+	UserLoginLog         userLoginLog
 }
 
 func (q *Query) Available() bool { return q.db != nil }
 
 func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
-		db:              db,
-		AlarmFilterWord: q.AlarmFilterWord.clone(db),
-		UserAuthInfo:    q.UserAuthInfo.clone(db),
-		UserLoginLog:    q.UserLoginLog.clone(db),
+		db:                   db,
+		AlarmFilterWord:      q.AlarmFilterWord.clone(db),
+		InterceptRuleVersion: q.InterceptRuleVersion.clone(db),
+		UserAuthInfo:         q.UserAuthInfo.clone(db),
+		UserLoginLog:         q.UserLoginLog.clone(db),
 	}
 }
 
@@ -53,24 +56,27 @@ func (q *Query) WriteDB() *Query {
 
 func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
-		db:              db,
-		AlarmFilterWord: q.AlarmFilterWord.replaceDB(db),
-		UserAuthInfo:    q.UserAuthInfo.replaceDB(db),
-		UserLoginLog:    q.UserLoginLog.replaceDB(db),
+		db:                   db,
+		AlarmFilterWord:      q.AlarmFilterWord.replaceDB(db),
+		InterceptRuleVersion: q.InterceptRuleVersion.replaceDB(db),
+		UserAuthInfo:         q.UserAuthInfo.replaceDB(db),
+		UserLoginLog:         q.UserLoginLog.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
-	AlarmFilterWord IAlarmFilterWordDo
-	UserAuthInfo    IUserAuthInfoDo
-	UserLoginLog    IUserLoginLogDo
+	AlarmFilterWord      IAlarmFilterWordDo
+	InterceptRuleVersion IInterceptRuleVersionDo
+	UserAuthInfo         IUserAuthInfoDo
+	UserLoginLog         IUserLoginLogDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
-		AlarmFilterWord: q.AlarmFilterWord.WithContext(ctx),
-		UserAuthInfo:    q.UserAuthInfo.WithContext(ctx),
-		UserLoginLog:    q.UserLoginLog.WithContext(ctx),
+		AlarmFilterWord:      q.AlarmFilterWord.WithContext(ctx),
+		InterceptRuleVersion: q.InterceptRuleVersion.WithContext(ctx),
+		UserAuthInfo:         q.UserAuthInfo.WithContext(ctx),
+		UserLoginLog:         q.UserLoginLog.WithContext(ctx),
 	}
 }
 
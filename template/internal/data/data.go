@@ -7,7 +7,9 @@ import (
 // ProviderSet is data providers.
 var ProviderSet = wire.NewSet(
 	NewAlarmMessageRepo, NewAlarm,
+	NewTransaction, NewOutbox, NewEventPublisher,
 	NewAuthRepo, NewAuthLogRepo,
 	NewGeoIP,
 	NewHealthRepo,
+	NewInterceptRuleRepo,
 )
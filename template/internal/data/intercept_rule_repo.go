@@ -0,0 +1,85 @@
+package data
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/seanbit/kratos/template/internal/biz"
+	"github.com/seanbit/kratos/template/internal/data/dao"
+	"github.com/seanbit/kratos/template/internal/data/model"
+	"github.com/seanbit/kratos/template/internal/infra"
+	"github.com/seanbit/kratos/webkit"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type interceptRuleRepo struct {
+	dbProvider  infra.PostgresProvider
+	rdbProvider infra.RedisProvider
+}
+
+func NewInterceptRuleRepo(dbProvider infra.PostgresProvider, rdbProvider infra.RedisProvider) biz.IInterceptRuleRepo {
+	return &interceptRuleRepo{dbProvider: dbProvider, rdbProvider: rdbProvider}
+}
+
+func (repo *interceptRuleRepo) ListVersions(ctx context.Context, serverName string, offset, limit int) ([]*model.InterceptRuleVersion, int64, error) {
+	q := dao.Use(repo.dbProvider.GetDB()).InterceptRuleVersion
+	records, count, err := q.WithContext(ctx).Where(
+		q.ServerName.Eq(serverName),
+	).Order(q.Version.Desc()).FindByPage(offset, limit)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "data: list intercept rule versions")
+	}
+	return records, count, nil
+}
+
+func (repo *interceptRuleRepo) GetVersion(ctx context.Context, serverName string, version int64) (*model.InterceptRuleVersion, error) {
+	q := dao.Use(repo.dbProvider.GetDB()).InterceptRuleVersion
+	record, err := q.WithContext(ctx).Where(
+		q.ServerName.Eq(serverName),
+		q.Version.Eq(version),
+	).Take()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "data: get intercept rule version")
+	}
+	return record, nil
+}
+
+func (repo *interceptRuleRepo) GetLatestVersion(ctx context.Context, serverName string) (*model.InterceptRuleVersion, error) {
+	q := dao.Use(repo.dbProvider.GetDB()).InterceptRuleVersion
+	record, err := q.WithContext(ctx).Where(
+		q.ServerName.Eq(serverName),
+	).Order(q.Version.Desc()).Take()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "data: get latest intercept rule version")
+	}
+	return record, nil
+}
+
+// CreateVersion 在 InTx 中调用时与变更事件同时提交
+// (server_name, version) 唯一，并发修改同一版本时只有一个能写入
+func (repo *interceptRuleRepo) CreateVersion(ctx context.Context, record *model.InterceptRuleVersion) error {
+	q := dao.Use(getDB(ctx, repo.dbProvider)).InterceptRuleVersion
+	err := q.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	if err != nil {
+		return errors.Wrap(err, "data: create intercept rule version")
+	}
+	if record.ID == 0 {
+		return biz.ErrInterceptRulesConflict
+	}
+	return nil
+}
+
+// PublishConfig 覆盖写入整份配置并通知订阅方，可安全重试
+func (repo *interceptRuleRepo) PublishConfig(ctx context.Context, serverName string, conf *webkit.InterceptConfig) error {
+	if err := webkit.PublishInterceptConfig(ctx, repo.rdbProvider.GetRedis(), serverName, conf); err != nil {
+		return errors.Wrap(err, "data: publish intercept rules")
+	}
+	return nil
+}
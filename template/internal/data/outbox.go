@@ -0,0 +1,37 @@
+package data
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/seanbit/kratos/template/internal/infra"
+	"github.com/seanbit/kratos/webkit"
+	"google.golang.org/protobuf/proto"
+)
+
+// OutboxTable 发件箱表，建表语句见 sql/index_backend.outbox_message.sql
+const OutboxTable = "index_backend." + webkit.DefaultOutboxTable
+
+func NewOutbox() *webkit.Outbox {
+	return webkit.NewOutbox(OutboxTable)
+}
+
+type outboxEventPublisher struct {
+	dbProvider infra.PostgresProvider
+	outbox     *webkit.Outbox
+}
+
+// NewEventPublisher 领域事件写入发件箱，由 OutboxRelay 投递到 asynq
+func NewEventPublisher(dbProvider infra.PostgresProvider, outbox *webkit.Outbox) webkit.EventPublisher {
+	return &outboxEventPublisher{dbProvider: dbProvider, outbox: outbox}
+}
+
+// Publish 在 InTx 中调用时与业务数据同时提交或回滚
+func (p *outboxEventPublisher) Publish(ctx context.Context, msg proto.Message) error {
+	record, err := p.outbox.Add(ctx, getDB(ctx, p.dbProvider), msg)
+	if err != nil {
+		return err
+	}
+	log.Context(ctx).Infof("publish %s outbox id: %s", record.Topic, record.ID)
+	return nil
+}
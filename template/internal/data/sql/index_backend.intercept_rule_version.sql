@@ -0,0 +1,13 @@
+-- 流量拦截规则版本，每次变更（包括回滚）追加一条记录，最新版本即为线上生效的规则
+CREATE TABLE IF NOT EXISTS index_backend.intercept_rule_version
+(
+    id            BIGSERIAL PRIMARY KEY,
+    server_name   CHARACTER VARYING(128) NOT NULL,
+    version       BIGINT                 NOT NULL,
+    config        JSONB                  NOT NULL,
+    author        CHARACTER VARYING(128) NOT NULL,
+    comment       CHARACTER VARYING(512) NOT NULL DEFAULT '',
+    rollback_from BIGINT                 NOT NULL DEFAULT 0,
+    created_time  TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uk_intercept_rule_version UNIQUE (server_name, version)
+);
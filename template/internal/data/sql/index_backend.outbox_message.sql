@@ -0,0 +1,15 @@
+-- 事务发件箱，领域事件与业务数据在同一事务中写入，由 outbox relay 投递到 asynq 后标记 published_at
+CREATE TABLE IF NOT EXISTS index_backend.outbox_message
+(
+    id           CHARACTER VARYING(64)    PRIMARY KEY,
+    topic        CHARACTER VARYING(256)   NOT NULL,
+    payload      BYTEA                    NOT NULL,
+    headers      TEXT                     NOT NULL DEFAULT '',
+    attempts     INTEGER                  NOT NULL DEFAULT 0,
+    last_error   TEXT                     NOT NULL DEFAULT '',
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+    available_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    published_at TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_message_pending ON index_backend.outbox_message (available_at) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_message_published ON index_backend.outbox_message (published_at) WHERE published_at IS NOT NULL;
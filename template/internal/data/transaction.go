@@ -0,0 +1,37 @@
+package data
+
+import (
+	"context"
+
+	"github.com/seanbit/kratos/template/internal/biz"
+	"github.com/seanbit/kratos/template/internal/infra"
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+type transaction struct {
+	dbProvider infra.PostgresProvider
+}
+
+func NewTransaction(dbProvider infra.PostgresProvider) biz.ITransaction {
+	return &transaction{dbProvider: dbProvider}
+}
+
+// InTx fn 中通过 getDB(ctx, ...) 获取的连接均在同一事务中，fn 返回错误时回滚
+func (t *transaction) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+	return t.dbProvider.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// getDB 在 InTx 中返回事务连接，否则返回默认连接
+func getDB(ctx context.Context, dbProvider infra.PostgresProvider) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx
+	}
+	return dbProvider.GetDB()
+}
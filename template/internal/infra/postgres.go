@@ -26,6 +26,8 @@ type postgresProvider struct {
 	db       *gorm.DB
 	cleaning *atomic.Bool
 	cancel   context.CancelFunc
+	// unregisterMetrics 停止采集连接池指标
+	unregisterMetrics func()
 }
 
 func NewPostgresProvider(dbCnf *conf.Data_Database) (PostgresProvider, func(), error) {
@@ -87,11 +89,21 @@ func NewPostgresProvider(dbCnf *conf.Data_Database) (PostgresProvider, func(), e
 	sqlDB.SetConnMaxLifetime(dbCnf.ConnMaxLifetime.AsDuration())
 	sqlDB.SetConnMaxIdleTime(dbCnf.ConnMaxIdleTime.AsDuration())
 
+	// 连接池指标
+	unregisterMetrics, err := webkit.RegisterDBStatsMetrics("postgres", sqlDB)
+	if err != nil {
+		if closeErr := sqlDB.Close(); closeErr != nil {
+			log.Warnf("postgres Close error: %+v", closeErr)
+		}
+		return nil, nil, err
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	provider := &postgresProvider{
-		db:       masterDB,
-		cleaning: &atomic.Bool{},
-		cancel:   cancel,
+		db:                masterDB,
+		cleaning:          &atomic.Bool{},
+		cancel:            cancel,
+		unregisterMetrics: unregisterMetrics,
 	}
 	go provider.CheckDbConnection(ctx)
 	return provider, provider.Close, nil
@@ -111,6 +123,9 @@ func (p *postgresProvider) Close() {
 	if p.cancel != nil {
 		p.cancel()
 	}
+	if p.unregisterMetrics != nil {
+		p.unregisterMetrics()
+	}
 
 	sqlDB, err := p.GetDB().DB()
 	if err != nil {
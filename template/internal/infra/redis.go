@@ -10,6 +10,7 @@ import (
 	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 	"github.com/seanbit/kratos/template/internal/conf"
+	"github.com/seanbit/kratos/webkit"
 )
 
 const (
@@ -29,6 +30,8 @@ type redisProvider struct {
 	client        *redis.Client
 	clusterClient *redis.ClusterClient
 	cleaning      *atomic.Bool
+	// unregisterMetrics 停止采集连接池指标
+	unregisterMetrics func()
 }
 
 func NewRedisProvider(config *conf.Data_Redis) (RedisProvider, func(), error) {
@@ -42,7 +45,12 @@ func NewRedisProvider(config *conf.Data_Redis) (RedisProvider, func(), error) {
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "new redis provider")
 	}
-	return provider, provider.Close, err
+	provider.unregisterMetrics, err = webkit.RegisterRedisPoolMetrics("redis", provider.GetRedis())
+	if err != nil {
+		provider.Close()
+		return nil, nil, errors.Wrap(err, "new redis provider")
+	}
+	return provider, provider.Close, nil
 }
 
 func (p *redisProvider) GetRedis() redis.UniversalClient {
@@ -58,6 +66,9 @@ func (p *redisProvider) Close() {
 		return
 	}
 	p.cleaning.Store(true)
+	if p.unregisterMetrics != nil {
+		p.unregisterMetrics()
+	}
 	if p.client != nil {
 		if err := p.client.Close(); err != nil {
 			log.Warnf("redis Close error: %+v", err)
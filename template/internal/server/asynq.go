@@ -1,32 +1,78 @@
 package server
 
 import (
-	"context"
-
 	"github.com/go-kratos/kratos/v2/log"
 	asynq2 "github.com/hibiken/asynq"
-	"github.com/seanbit/kratos/template/api/event"
 	"github.com/seanbit/kratos/template/internal/conf"
+	"github.com/seanbit/kratos/template/internal/crontab"
+	"github.com/seanbit/kratos/template/internal/service"
+	"github.com/seanbit/kratos/webkit"
 	"github.com/seanbit/kratos/webkit/transport/asynq"
 )
 
-func NewAsynqClient(config *conf.Server) (*asynq2.Client, error) {
+// NewAsynqClient 投递的任务携带链路信息，消费端与请求处于同一 trace
+func NewAsynqClient(config *conf.Server) (*asynq.Client, error) {
 	redisConnOpts, err := asynq2.ParseRedisURI(config.Asynq.RedisUri)
 	if err != nil {
 		return nil, err
 	}
-	return asynq2.NewClient(redisConnOpts), nil
+	return asynq.NewClient(asynq2.NewClient(redisConnOpts)), nil
+}
+
+// NewAsynqInspector 注册队列深度、延迟与重试/归档数指标
+func NewAsynqInspector(config *conf.Server) (*asynq2.Inspector, func(), error) {
+	redisConnOpts, err := asynq2.ParseRedisURI(config.Asynq.RedisUri)
+	if err != nil {
+		return nil, nil, err
+	}
+	inspector := asynq2.NewInspector(redisConnOpts)
+	unregister, err := webkit.RegisterAsynqQueueMetrics(inspector)
+	if err != nil {
+		_ = inspector.Close()
+		return nil, nil, err
+	}
+	return inspector, func() {
+		unregister()
+		if err := inspector.Close(); err != nil {
+			log.Warnf("asynq inspector Close error: %+v", err)
+		}
+	}, nil
+}
+
+// NewAsynqAdmin 供 TaskAdminService 查看、重放失败与归档的任务
+func NewAsynqAdmin(inspector *asynq2.Inspector) *asynq.Admin {
+	return asynq.NewAdmin(inspector)
 }
 
-func NewAsynqServer(config *conf.Server, logger log.Logger, handler event.EventHandlerServer) *asynq.Server {
+func NewAsynqServer(config *conf.Server, logger log.Logger, client *asynq.Client, events *service.EventService, periodic *crontab.PeriodicTest) (*asynq.Server, error) {
+	// 未注册的任务类型直接归档，便于在管理后台排查
+	router := asynq.NewRouter(asynq.WithUnknownTaskPolicy(asynq.UnknownTaskArchive), asynq.WithRouterLogger(logger))
+	if err := events.Register(webkit.NewAsynqEventBus(client, router)); err != nil {
+		return nil, err
+	}
+	events.RegisterBatch(router)
+	periodic.Register(router)
+
 	opts := []asynq.ServerOption{
 		asynq.WithRedisURI(config.Asynq.RedisUri),
 		asynq.WithLogger(logger),
-		asynq.WithHandler(newAsynqProcesser(handler)),
+		asynq.WithHandler(router),
+		asynq.WithMiddleware(webkit.TaskMiddleWare()...),
+		// PublishGrouped 投递的事件按类型聚合，由 HandleBatch 注册的处理函数批量处理
+		asynq.WithGroupAggregator(asynq.BatchAggregator()),
 	}
 	if config.Asynq.Concurrency > 0 {
 		opts = append(opts, asynq.WithConcurrency(int(config.Asynq.Concurrency)))
 	}
+	if config.Asynq.GroupGracePeriod != nil {
+		opts = append(opts, asynq.WithGroupGracePeriod(config.Asynq.GroupGracePeriod.AsDuration()))
+	}
+	if config.Asynq.GroupMaxDelay != nil {
+		opts = append(opts, asynq.WithGroupMaxDelay(config.Asynq.GroupMaxDelay.AsDuration()))
+	}
+	if config.Asynq.GroupMaxSize > 0 {
+		opts = append(opts, asynq.WithGroupMaxSize(int(config.Asynq.GroupMaxSize)))
+	}
 	if len(config.Asynq.Queues) > 0 {
 		queues := make(map[string]int, len(config.Asynq.Queues))
 		for name, queue := range config.Asynq.Queues {
@@ -34,22 +80,14 @@ func NewAsynqServer(config *conf.Server, logger log.Logger, handler event.EventH
 		}
 		opts = append(opts, asynq.WithQueues(queues))
 	}
-	return asynq.NewServer(opts...)
-}
-
-type AsynqProcesser struct {
-	handler event.EventHandlerServer
-}
-
-func newAsynqProcesser(handler event.EventHandlerServer) *AsynqProcesser {
-	return &AsynqProcesser{handler: handler}
+	return asynq.NewServer(opts...), nil
 }
 
-func (p *AsynqProcesser) ProcessTask(ctx context.Context, task *asynq2.Task) error {
-	_, err := p.handler.HandleEvent(ctx, &event.Event{
-		Id:      task.ResultWriter().TaskID(),
-		Name:    task.Type(),
-		Payload: task.Payload(),
-	})
-	return err
+// NewAsynqScheduler 投递 crontab.PeriodicTaskRegister 中声明的周期任务
+func NewAsynqScheduler(config *conf.Server, logger log.Logger, register asynq.PeriodicTaskRegister) *asynq.Scheduler {
+	return asynq.NewScheduler(
+		asynq.WithSchedulerRedisURI(config.Asynq.RedisUri),
+		asynq.WithSchedulerLogger(logger),
+		asynq.WithPeriodicTasks(register),
+	)
 }
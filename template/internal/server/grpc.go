@@ -3,16 +3,21 @@ package server
 import (
 	"github.com/seanbit/kratos/template/api/web"
 	"github.com/seanbit/kratos/template/internal/conf"
+	"github.com/seanbit/kratos/template/internal/server/middlewares"
 	"github.com/seanbit/kratos/template/internal/service"
 	"github.com/seanbit/kratos/webkit"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/middleware/selector"
 	"github.com/go-kratos/kratos/v2/transport/grpc"
 )
 
 // NewGRPCServer new a gRPC server.
-func NewGRPCServer(c *conf.Server, probe *service.ProbeService, logger log.Logger) *grpc.Server {
+func NewGRPCServer(c *conf.Server, probe *service.ProbeService, interceptAdmin *service.InterceptAdminService,
+	userAuth *middlewares.UserAuth, logger log.Logger,
+	interceptor *webkit.TrafficInterceptor, loadShedder *webkit.LoadShedder, rateLimiter *webkit.RateLimiter,
+) *grpc.Server {
 	var opts = []grpc.ServerOption{
 		grpc.Middleware(
 			recovery.Recovery(),
@@ -27,9 +32,13 @@ func NewGRPCServer(c *conf.Server, probe *service.ProbeService, logger log.Logge
 	if c.Grpc.Timeout != nil {
 		opts = append(opts, grpc.Timeout(c.Grpc.Timeout.AsDuration()))
 	}
-	middlewares := webkit.PrepareMiddleWare()
-	opts = append(opts, grpc.Middleware(middlewares...))
+	middlewareFns := webkit.PrepareMiddleWare(webkit.WithMiddlewareLoadShedder(loadShedder))
+	// 管理接口与 HTTP 一致，需为 admin_users 中的登录用户
+	middlewareFns = append(middlewareFns, selector.Server(userAuth.Middleware()).Prefix("/web.InterceptAdmin/").Build())
+	middlewareFns = append(middlewareFns, interceptor.Middleware(), rateLimiter.Middleware())
+	opts = append(opts, grpc.Middleware(middlewareFns...))
 	srv := grpc.NewServer(opts...)
 	web.RegisterProbeServer(srv, probe)
+	web.RegisterInterceptAdminServer(srv, interceptAdmin)
 	return srv
 }
@@ -21,7 +21,9 @@ import (
 
 // NewHTTPServer new an HTTP server.
 func NewHTTPServer(c *conf.Server, logger log.Logger, middlewaresBuilder *middlewares.HttpBuilder,
-	probe *service.ProbeService, alarm biz.IAlarmRepo, auth *service.AuthService,
+	probe *service.ProbeService, alarm biz.IAlarmRepo, auth *service.AuthService, interceptAdmin *service.InterceptAdminService,
+	taskAdmin *service.TaskAdminService,
+	interceptor *webkit.TrafficInterceptor, loadShedder *webkit.LoadShedder, rateLimiter *webkit.RateLimiter,
 ) *khttp.Server {
 	var opts = []khttp.ServerOption{
 		khttp.Filter(handlers.CORS(
@@ -64,7 +66,7 @@ func NewHTTPServer(c *conf.Server, logger log.Logger, middlewaresBuilder *middle
 		opts = append(opts, khttp.Timeout(c.Http.Timeout.AsDuration()))
 	}
 
-	middlewareFns := webkit.PrepareMiddleWare()
+	middlewareFns := webkit.PrepareMiddleWare(webkit.WithMiddlewareLoadShedder(loadShedder))
 	middlewareFns = append(middlewareFns, InjectContextMiddleware())
 	recoverFunc := func(ctx context.Context, req, err interface{}) error {
 		alarm.SendBizMessage(ctx, "panic error", "panic error")
@@ -75,11 +77,15 @@ func NewHTTPServer(c *conf.Server, logger log.Logger, middlewaresBuilder *middle
 	middlewareFns = append(middlewareFns,
 		middlewaresBuilder.Build()...,
 	)
+	// 流量拦截与限流放在鉴权之后，登录用户按 uid 匹配与计数
+	middlewareFns = append(middlewareFns, interceptor.Middleware(), rateLimiter.Middleware())
 	opts = append(opts, khttp.Middleware(middlewareFns...))
 
 	srv := khttp.NewServer(opts...)
 	web.RegisterProbeHTTPServer(srv, probe)
 	web.RegisterAuthHTTPServer(srv, auth)
+	web.RegisterInterceptAdminHTTPServer(srv, interceptAdmin)
+	web.RegisterTaskAdminHTTPServer(srv, taskAdmin)
 	srv.Handle("/metrics", promhttp.Handler())
 
 	return srv
@@ -0,0 +1,25 @@
+package server
+
+import (
+	"github.com/seanbit/kratos/template/internal/biz"
+	"github.com/seanbit/kratos/template/internal/conf"
+	"github.com/seanbit/kratos/template/internal/global"
+	"github.com/seanbit/kratos/template/internal/infra"
+	"github.com/seanbit/kratos/webkit"
+)
+
+// NewTrafficInterceptor 初始化 HTTP 与 gRPC 共用的流量拦截器，同时作为包级 API 使用的默认实例
+func NewTrafficInterceptor(c *conf.Server, redis infra.RedisProvider, alarm biz.IAlarmRepo) (*webkit.TrafficInterceptor, func()) {
+	ic := c.GetIntercept()
+	signCfg := &webkit.SignConfig{
+		Enabled:         ic.GetSignEnabled(),
+		Secret:          ic.GetSignSecret(),
+		SignatureLength: int(ic.GetSignatureLength()),
+		MaxTimeDrift:    ic.GetMaxTimeDrift(),
+		RejectV1:        ic.GetRejectV1(),
+	}
+	webkit.InitInterceptConfig(global.GetServiceName(), redis.GetRedis(), signCfg)
+	interceptor := webkit.DefaultTrafficInterceptor()
+	interceptor.SetNotifier(alarm)
+	return interceptor, webkit.StopInterceptConfig
+}
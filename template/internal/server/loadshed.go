@@ -0,0 +1,34 @@
+package server
+
+import (
+	"github.com/seanbit/kratos/template/api/web"
+	"github.com/seanbit/kratos/template/internal/conf"
+	"github.com/seanbit/kratos/webkit"
+)
+
+// NewLoadShedder 初始化 HTTP 与 gRPC 共用的过载保护实例，同时作为包级 API 使用的默认实例
+// 未配置 load_shed 时返回 nil，不启用过载保护
+func NewLoadShedder(c *conf.Server) (*webkit.LoadShedder, error) {
+	lc := c.GetLoadShed()
+	if lc == nil {
+		return nil, nil
+	}
+	rules := make([]webkit.LoadShedRule, 0, len(lc.GetRules()))
+	for _, r := range lc.GetRules() {
+		rules = append(rules, webkit.LoadShedRule{
+			Operations: r.GetOperations(),
+			Priority:   r.GetPriority(),
+		})
+	}
+	err := webkit.InitLoadShed(&webkit.LoadShedConfig{
+		Rules:           rules,
+		DefaultPriority: lc.GetDefaultPriority(),
+		InitialLimit:    int(lc.GetInitialLimit()),
+		MinLimit:        int(lc.GetMinLimit()),
+		MaxLimit:        int(lc.GetMaxLimit()),
+	}, webkit.WithLoadShedPriorityResolver(webkit.ProtoPriorityResolver(web.E_Priority)))
+	if err != nil {
+		return nil, err
+	}
+	return webkit.DefaultLoadShedder(), nil
+}
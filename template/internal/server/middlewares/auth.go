@@ -25,14 +25,20 @@ func NewUserAuth(userInfoServ *biz.Auth) *UserAuth {
 }
 
 func (mw *UserAuth) Build() middleware.Middleware {
-	handler := func(handler middleware.Handler) middleware.Handler {
+	return selector.Server(mw.Middleware()).Match(NewWhiteListMatcher()).Build()
+}
+
+// Middleware 校验登录 token 并写入用户信息，不含白名单
+// gRPC 调用方通过 x-md-global-jwt-key-gen 或 authorization 元数据传递 token
+func (mw *UserAuth) Middleware() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
 		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
 			var (
 				jwtToken string
 				platform string
 			)
 
-			if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("x-md-global-jwt-key-gen")) > 0 {
 				jwtToken = md.Get("x-md-global-jwt-key-gen")[0]
 			} else if tr, ok := transport.FromServerContext(ctx); ok {
 				jwtToken, err = webkit.FromAuthHeader(tr)
@@ -58,7 +64,6 @@ func (mw *UserAuth) Build() middleware.Middleware {
 			return
 		}
 	}
-	return selector.Server(handler).Match(NewWhiteListMatcher()).Build()
 }
 
 // NewWhiteListMatcher jwt白名单，在名单中的路由不用校验jwt
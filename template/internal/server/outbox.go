@@ -0,0 +1,51 @@
+package server
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	asynq2 "github.com/hibiken/asynq"
+	"github.com/seanbit/kratos/template/api/event"
+	"github.com/seanbit/kratos/template/internal/conf"
+	"github.com/seanbit/kratos/template/internal/data"
+	"github.com/seanbit/kratos/template/internal/infra"
+	"github.com/seanbit/kratos/webkit"
+	"github.com/seanbit/kratos/webkit/transport/asynq"
+	"google.golang.org/protobuf/proto"
+)
+
+// NewOutboxRelay 将发件箱中的事件投递到 asynq，以发件箱消息 ID 作为 TaskID 去重
+func NewOutboxRelay(c *conf.Server, dbProvider infra.PostgresProvider, client *asynq.Client, logger log.Logger) *webkit.OutboxRelay {
+	oc := c.GetOutbox()
+	var taskOpts []asynq2.Option
+	if oc.GetMaxRetry() > 0 {
+		taskOpts = append(taskOpts, asynq2.MaxRetry(int(oc.GetMaxRetry())))
+	}
+	if oc.GetTaskTimeout() != nil {
+		taskOpts = append(taskOpts, asynq2.Timeout(oc.GetTaskTimeout().AsDuration()))
+	}
+	opts := []webkit.OutboxRelayOption{webkit.WithOutboxLogger(logger)}
+	if oc.GetInterval() != nil {
+		opts = append(opts, webkit.WithOutboxInterval(oc.GetInterval().AsDuration()))
+	}
+	if oc.GetBatchSize() > 0 {
+		opts = append(opts, webkit.WithOutboxBatchSize(int(oc.GetBatchSize())))
+	}
+	if oc.GetRetention() != nil {
+		opts = append(opts, webkit.WithOutboxRetention(oc.GetRetention().AsDuration()))
+	}
+	// 登录事件以类型为组投递，由 asynq 服务端聚合后批量写入，见 asynq.PublishGrouped
+	loginTopic := string(proto.MessageName(&event.UserLogin{}))
+	publisher := webkit.NewAsynqOutboxPublisher(client, taskOpts...)
+	grouped := webkit.NewAsynqOutboxPublisher(client, append(append([]asynq2.Option{}, taskOpts...), asynq2.Group(loginTopic))...)
+	return webkit.NewOutboxRelay(
+		webkit.NewGormOutboxStore(dbProvider.GetDB(), data.OutboxTable),
+		webkit.OutboxPublisherFunc(func(ctx context.Context, msg *webkit.OutboxMessage) error {
+			if msg.Topic == loginTopic {
+				return grouped.PublishOutbox(ctx, msg)
+			}
+			return publisher.PublishOutbox(ctx, msg)
+		}),
+		opts...,
+	)
+}
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/seanbit/kratos/template/internal/conf"
+	"github.com/seanbit/kratos/template/internal/global"
+	"github.com/seanbit/kratos/template/internal/infra"
+	"github.com/seanbit/kratos/webkit"
+)
+
+// NewRateLimiter HTTP 与 gRPC 共用的分布式限流器，按 server.rate_limit 配置各 operation 的配额
+// period 须为整数秒，未配置时为1秒
+func NewRateLimiter(c *conf.Server, redis infra.RedisProvider) (*webkit.RateLimiter, error) {
+	rc := c.GetRateLimit()
+	rules := make([]webkit.RateLimitRule, 0, len(rc.GetRules()))
+	for i, r := range rc.GetRules() {
+		period := r.GetPeriod().AsDuration()
+		if period < 0 || period%time.Second != 0 {
+			return nil, errors.Errorf("rate_limit.rules[%d]: period %s is not a whole number of seconds", i, period)
+		}
+		rules = append(rules, webkit.RateLimitRule{
+			Name:       r.GetName(),
+			Operations: r.GetOperations(),
+			Keys:       r.GetKeys(),
+			Rate:       int(r.GetRate()),
+			Period:     int(period / time.Second),
+			Burst:      int(r.GetBurst()),
+		})
+	}
+	return webkit.NewRateLimiter(
+		webkit.NewRedisRateLimitStore(redis.GetRedis()),
+		&webkit.RateLimitConfig{Rules: rules, FailClosed: rc.GetFailClosed()},
+		webkit.WithRateLimitServerName(global.GetServiceName()),
+	)
+}
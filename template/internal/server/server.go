@@ -10,9 +10,16 @@ import (
 var ProviderSet = wire.NewSet(
 	middlewares.NewUserAuth,
 	middlewares.NewHttpBuilder,
+	NewTrafficInterceptor,
+	NewRateLimiter,
+	NewLoadShedder,
 	NewGRPCServer,
 	NewHTTPServer,
 	NewAsynqServer,
 	NewAsynqClient,
+	NewAsynqInspector,
+	NewAsynqAdmin,
+	NewAsynqScheduler,
+	NewOutboxRelay,
 	crontab.NewServer,
 )
@@ -5,42 +5,57 @@ import (
 
 	"github.com/seanbit/kratos/template/api/event"
 	"github.com/seanbit/kratos/template/internal/biz"
-	"google.golang.org/protobuf/proto"
-	"google.golang.org/protobuf/types/known/emptypb"
-)
-
-var (
-	EventTypeUserLogin = string(proto.MessageName(&event.UserLogin{}))
+	"github.com/seanbit/kratos/webkit"
+	"github.com/seanbit/kratos/webkit/transport/asynq"
 )
 
 type EventService struct {
-	event.UnimplementedEventHandlerServer
-	auth *biz.Auth
+	auth      *biz.Auth
+	intercept *biz.InterceptRule
 }
 
-func NewEventService(auth *biz.Auth) event.EventHandlerServer {
-	return &EventService{auth: auth}
+func NewEventService(auth *biz.Auth, intercept *biz.InterceptRule) *EventService {
+	return &EventService{auth: auth, intercept: intercept}
 }
 
-func (serv *EventService) HandleEvent(ctx context.Context, e *event.Event) (*emptypb.Empty, error) {
-	switch e.Name {
-	case EventTypeUserLogin:
-		return serv.handleUserLoginEvent(ctx, e)
+// Register 订阅领域事件，topic 为事件消息的 proto 全名
+func (serv *EventService) Register(sub webkit.EventSubscriber) error {
+	if err := webkit.SubscribeEvent(sub, serv.handleUserLoginEvent); err != nil {
+		return err
 	}
-	return &emptypb.Empty{}, nil
+	return webkit.SubscribeEvent(sub, serv.handleInterceptRulesChangedEvent)
 }
 
-func (serv *EventService) handleUserLoginEvent(ctx context.Context, e *event.Event) (*emptypb.Empty, error) {
-	message := &event.UserLogin{}
-	if err := proto.Unmarshal(e.Payload, message); err != nil {
-		return nil, err
-	}
-	err := serv.auth.SaveUserLoginLog(ctx, &biz.UserLoginLog{
+// RegisterBatch 注册聚合事件处理函数，登录事件按组聚合后批量写入
+func (serv *EventService) RegisterBatch(r *asynq.Router) {
+	asynq.HandleBatch(r, serv.handleUserLoginEvents)
+}
+
+func (serv *EventService) handleUserLoginEvent(ctx context.Context, message *event.UserLogin) error {
+	return serv.auth.SaveUserLoginLog(ctx, &biz.UserLoginLog{
 		UserId:     message.UserId,
 		AuthType:   message.AuthType,
 		LoginIp:    message.Ip,
 		LoginTime:  message.Timestamp.AsTime(),
 		IssueToken: message.IssueToken,
 	})
-	return &emptypb.Empty{}, err
+}
+
+// handleInterceptRulesChangedEvent 发布最新版本的拦截规则，失败时由 asynq 重试
+func (serv *EventService) handleInterceptRulesChangedEvent(ctx context.Context, message *event.InterceptRulesChanged) error {
+	return serv.intercept.PublishLatest(ctx, message.ServerName)
+}
+
+func (serv *EventService) handleUserLoginEvents(ctx context.Context, messages []*event.UserLogin) error {
+	logs := make([]*biz.UserLoginLog, 0, len(messages))
+	for _, message := range messages {
+		logs = append(logs, &biz.UserLoginLog{
+			UserId:     message.UserId,
+			AuthType:   message.AuthType,
+			LoginIp:    message.Ip,
+			LoginTime:  message.Timestamp.AsTime(),
+			IssueToken: message.IssueToken,
+		})
+	}
+	return serv.auth.SaveUserLoginLogs(ctx, logs)
 }
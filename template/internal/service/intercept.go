@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+
+	pb "github.com/seanbit/kratos/template/api/web"
+	"github.com/seanbit/kratos/template/internal/biz"
+	"github.com/seanbit/kratos/template/internal/conf"
+	"github.com/seanbit/kratos/template/internal/data/model"
+	"github.com/seanbit/kratos/template/internal/global"
+	"github.com/seanbit/kratos/webkit"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type InterceptAdminService struct {
+	pb.UnimplementedInterceptAdminServer
+	interceptBiz *biz.InterceptRule
+	admins       map[string]struct{}
+}
+
+func NewInterceptAdminService(c *conf.Server, interceptBiz *biz.InterceptRule) *InterceptAdminService {
+	admins := make(map[string]struct{})
+	for _, userId := range c.GetIntercept().GetAdminUsers() {
+		admins[userId] = struct{}{}
+	}
+	return &InterceptAdminService{interceptBiz: interceptBiz, admins: admins}
+}
+
+func (s *InterceptAdminService) ListInterceptRuleVersions(ctx context.Context, req *pb.ListInterceptRuleVersionsRequest) (*pb.ListInterceptRuleVersionsResponse, error) {
+	if _, err := s.operator(ctx); err != nil {
+		return nil, err
+	}
+	records, total, err := s.interceptBiz.ListVersions(ctx, serverNameOrDefault(req.ServerName), int(req.Page), int(req.PageSize))
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]*pb.InterceptRuleVersion, 0, len(records))
+	for _, record := range records {
+		versions = append(versions, toInterceptRuleVersion(record))
+	}
+	return &pb.ListInterceptRuleVersionsResponse{Versions: versions, Total: total}, nil
+}
+
+func (s *InterceptAdminService) ValidateInterceptRules(ctx context.Context, req *pb.ValidateInterceptRulesRequest) (*pb.ValidateInterceptRulesResponse, error) {
+	if _, err := s.operator(ctx); err != nil {
+		return nil, err
+	}
+	_, normalized, err := s.interceptBiz.ValidateConfig(req.Config)
+	if err != nil {
+		return &pb.ValidateInterceptRulesResponse{Valid: false, Error: err.Error()}, nil
+	}
+	return &pb.ValidateInterceptRulesResponse{Valid: true, Config: normalized}, nil
+}
+
+func (s *InterceptAdminService) CreateInterceptRules(ctx context.Context, req *pb.CreateInterceptRulesRequest) (*pb.InterceptRuleVersion, error) {
+	author, err := s.operator(ctx)
+	if err != nil {
+		return nil, err
+	}
+	record, err := s.interceptBiz.Create(ctx, serverNameOrDefault(req.ServerName), req.Config, req.Comment, author)
+	if err != nil {
+		return nil, err
+	}
+	return toInterceptRuleVersion(record), nil
+}
+
+func (s *InterceptAdminService) UpdateInterceptRules(ctx context.Context, req *pb.UpdateInterceptRulesRequest) (*pb.InterceptRuleVersion, error) {
+	author, err := s.operator(ctx)
+	if err != nil {
+		return nil, err
+	}
+	record, err := s.interceptBiz.Update(ctx, serverNameOrDefault(req.ServerName), req.BaseVersion, req.Config, req.Comment, author)
+	if err != nil {
+		return nil, err
+	}
+	return toInterceptRuleVersion(record), nil
+}
+
+func (s *InterceptAdminService) RollbackInterceptRules(ctx context.Context, req *pb.RollbackInterceptRulesRequest) (*pb.InterceptRuleVersion, error) {
+	author, err := s.operator(ctx)
+	if err != nil {
+		return nil, err
+	}
+	record, err := s.interceptBiz.Rollback(ctx, serverNameOrDefault(req.ServerName), req.Version, req.Comment, author)
+	if err != nil {
+		return nil, err
+	}
+	return toInterceptRuleVersion(record), nil
+}
+
+// operator 返回操作人，需为 admin_users 中的登录用户
+func (s *InterceptAdminService) operator(ctx context.Context) (string, error) {
+	return adminOperator(ctx, s.admins)
+}
+
+// adminOperator HTTP 与 gRPC 均由 UserAuth 写入登录用户，未登录或不在 admins 中时拒绝
+func adminOperator(ctx context.Context, admins map[string]struct{}) (string, error) {
+	userId, ok := webkit.UserIdFromContext(ctx)
+	if !ok {
+		return "", webkit.ErrAuthFail
+	}
+	if _, ok := admins[userId]; !ok {
+		return "", webkit.ErrAuthFail
+	}
+	return userId, nil
+}
+
+func serverNameOrDefault(serverName string) string {
+	if serverName == "" {
+		return global.GetServiceName()
+	}
+	return serverName
+}
+
+func toInterceptRuleVersion(record *model.InterceptRuleVersion) *pb.InterceptRuleVersion {
+	return &pb.InterceptRuleVersion{
+		ServerName:   record.ServerName,
+		Version:      record.Version,
+		Config:       record.Config,
+		Author:       record.Author,
+		Comment:      record.Comment,
+		RollbackFrom: record.RollbackFrom,
+		CreatedAt:    timestamppb.New(record.CreatedTime),
+	}
+}
@@ -7,4 +7,6 @@ var ProviderSet = wire.NewSet(
 	NewEventService,
 	NewProbeService,
 	NewAuthService,
+	NewInterceptAdminService,
+	NewTaskAdminService,
 )
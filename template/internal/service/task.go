@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	asynq2 "github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+	pb "github.com/seanbit/kratos/template/api/web"
+	"github.com/seanbit/kratos/template/internal/biz"
+	"github.com/seanbit/kratos/template/internal/conf"
+	"github.com/seanbit/kratos/webkit/transport/asynq"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const defaultTaskPageSize = 20
+
+// TaskAdminService asynq 队列与任务管理，仅注册在 HTTP server，调用方需为 asynq.admin_users 中的登录用户
+type TaskAdminService struct {
+	pb.UnimplementedTaskAdminServer
+	admin  *asynq.Admin
+	admins map[string]struct{}
+}
+
+func NewTaskAdminService(c *conf.Server, admin *asynq.Admin) *TaskAdminService {
+	admins := make(map[string]struct{})
+	for _, userId := range c.GetAsynq().GetAdminUsers() {
+		admins[userId] = struct{}{}
+	}
+	return &TaskAdminService{admin: admin, admins: admins}
+}
+
+func (s *TaskAdminService) ListTaskQueues(ctx context.Context, req *pb.ListTaskQueuesRequest) (*pb.ListTaskQueuesResponse, error) {
+	if _, err := adminOperator(ctx, s.admins); err != nil {
+		return nil, err
+	}
+	queues, err := s.admin.Queues()
+	if err != nil {
+		return nil, taskError(err)
+	}
+	reply := &pb.ListTaskQueuesResponse{Queues: make([]*pb.TaskQueue, 0, len(queues))}
+	for _, queue := range queues {
+		reply.Queues = append(reply.Queues, toTaskQueue(queue))
+	}
+	return reply, nil
+}
+
+func (s *TaskAdminService) PauseTaskQueue(ctx context.Context, req *pb.TaskQueueRequest) (*pb.TaskQueue, error) {
+	return s.setPaused(ctx, req.Queue, true)
+}
+
+func (s *TaskAdminService) ResumeTaskQueue(ctx context.Context, req *pb.TaskQueueRequest) (*pb.TaskQueue, error) {
+	return s.setPaused(ctx, req.Queue, false)
+}
+
+func (s *TaskAdminService) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
+	if _, err := adminOperator(ctx, s.admins); err != nil {
+		return nil, err
+	}
+	page, pageSize := int(req.Page), int(req.PageSize)
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = defaultTaskPageSize
+	}
+	tasks, total, err := s.admin.ListTasks(req.Queue, taskStateOrDefault(req.State), page, pageSize)
+	if err != nil {
+		return nil, taskError(err)
+	}
+	reply := &pb.ListTasksResponse{Tasks: make([]*pb.Task, 0, len(tasks)), Total: int64(total)}
+	for _, task := range tasks {
+		reply.Tasks = append(reply.Tasks, toTask(task))
+	}
+	return reply, nil
+}
+
+func (s *TaskAdminService) GetTask(ctx context.Context, req *pb.GetTaskRequest) (*pb.Task, error) {
+	if _, err := adminOperator(ctx, s.admins); err != nil {
+		return nil, err
+	}
+	task, err := s.admin.GetTask(req.Queue, req.Id)
+	if err != nil {
+		return nil, taskError(err)
+	}
+	return toTask(task), nil
+}
+
+func (s *TaskAdminService) RunTasks(ctx context.Context, req *pb.BatchTasksRequest) (*pb.BatchTasksResponse, error) {
+	return s.batch(ctx, "run", req, s.admin.RunTasks)
+}
+
+func (s *TaskAdminService) DeleteTasks(ctx context.Context, req *pb.BatchTasksRequest) (*pb.BatchTasksResponse, error) {
+	return s.batch(ctx, "delete", req, s.admin.DeleteTasks)
+}
+
+func (s *TaskAdminService) ArchiveTasks(ctx context.Context, req *pb.BatchTasksRequest) (*pb.BatchTasksResponse, error) {
+	return s.batch(ctx, "archive", req, s.admin.ArchiveTasks)
+}
+
+// batch ids 为空时须显式指定 state 才操作该状态下的全部任务，避免误删整个归档集
+// 指定 ids 时部分失败仍返回成功数，调用方可与 ids 数量比较；全部失败时返回错误
+func (s *TaskAdminService) batch(ctx context.Context, action string, req *pb.BatchTasksRequest,
+	fn func(queue string, state asynq.TaskState, ids []string) (int, error),
+) (*pb.BatchTasksResponse, error) {
+	operator, err := adminOperator(ctx, s.admins)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Ids) == 0 && req.State == "" {
+		return nil, biz.ErrTaskStateInvalid(errors.New("state is required when ids is empty"))
+	}
+	affected, err := fn(req.Queue, asynq.TaskState(req.State), req.Ids)
+	if err != nil {
+		if affected == 0 {
+			return nil, taskError(err)
+		}
+		log.Context(ctx).Warnf("%s tasks of queue %s partially failed: %v", action, req.Queue, err)
+	}
+	log.Context(ctx).Infof("%s %d tasks of queue %s by %s", action, affected, req.Queue, operator)
+	return &pb.BatchTasksResponse{Affected: int32(affected)}, nil
+}
+
+// setPaused 暂停或恢复队列，队列已处于目标状态时直接返回
+func (s *TaskAdminService) setPaused(ctx context.Context, queue string, paused bool) (*pb.TaskQueue, error) {
+	operator, err := adminOperator(ctx, s.admins)
+	if err != nil {
+		return nil, err
+	}
+	info, err := s.admin.Queue(queue)
+	if err != nil {
+		return nil, taskError(err)
+	}
+	if info.Paused == paused {
+		return toTaskQueue(info), nil
+	}
+	if paused {
+		err = s.admin.PauseQueue(queue)
+	} else {
+		err = s.admin.UnpauseQueue(queue)
+	}
+	if err != nil {
+		return nil, taskError(err)
+	}
+	log.Context(ctx).Infof("task queue %s paused=%t by %s", queue, paused, operator)
+	info.Paused = paused
+	return toTaskQueue(info), nil
+}
+
+func taskStateOrDefault(state string) asynq.TaskState {
+	if state == "" {
+		return asynq.TaskStateArchived
+	}
+	return asynq.TaskState(state)
+}
+
+// taskError 将 asynq 错误转换为业务错误码
+func taskError(err error) error {
+	switch {
+	case errors.Is(err, asynq2.ErrQueueNotFound):
+		return biz.ErrTaskQueueNotFound
+	case errors.Is(err, asynq2.ErrTaskNotFound):
+		return biz.ErrTaskNotFound
+	case errors.Is(err, asynq.ErrUnsupportedTaskState):
+		return biz.ErrTaskStateInvalid(err)
+	default:
+		return err
+	}
+}
+
+func toTaskQueue(info *asynq2.QueueInfo) *pb.TaskQueue {
+	return &pb.TaskQueue{
+		Queue:       info.Queue,
+		Size:        int32(info.Size),
+		Pending:     int32(info.Pending),
+		Active:      int32(info.Active),
+		Scheduled:   int32(info.Scheduled),
+		Retry:       int32(info.Retry),
+		Archived:    int32(info.Archived),
+		Completed:   int32(info.Completed),
+		Aggregating: int32(info.Aggregating),
+		Processed:   int32(info.Processed),
+		Failed:      int32(info.Failed),
+		Paused:      info.Paused,
+		Latency:     durationpb.New(info.Latency),
+		MemoryUsage: info.MemoryUsage,
+	}
+}
+
+func toTask(task *asynq.TaskDetail) *pb.Task {
+	return &pb.Task{
+		Id:            task.ID,
+		Queue:         task.Queue,
+		Type:          task.Type,
+		State:         task.State.String(),
+		Payload:       task.JSON,
+		RawPayload:    task.Payload,
+		MaxRetry:      int32(task.MaxRetry),
+		Retried:       int32(task.Retried),
+		LastErr:       task.LastErr,
+		LastFailedAt:  timestampOrNil(task.LastFailedAt),
+		NextProcessAt: timestampOrNil(task.NextProcessAt),
+		CompletedAt:   timestampOrNil(task.CompletedAt),
+		Group:         task.Group,
+	}
+}
+
+func timestampOrNil(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
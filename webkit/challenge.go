@@ -0,0 +1,325 @@
+package webkit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/middleware/selector"
+	"github.com/pkg/errors"
+)
+
+// 人机校验服务的 siteverify 地址
+const (
+	TurnstileVerifyURL = "https://challenges.cloudflare.com/turnstile/v0/siteverify"
+	HCaptchaVerifyURL  = "https://api.hcaptcha.com/siteverify"
+	ReCaptchaVerifyURL = "https://www.google.com/recaptcha/api/siteverify"
+
+	// ChallengeTokenHeader 客户端携带人机校验 token 的默认请求头
+	ChallengeTokenHeader = "Captcha-Token"
+
+	defaultChallengeTimeout = 5 * time.Second
+)
+
+// ChallengeMode 校验服务不可用（网络错误、超时、非 2xx）时的处理方式
+type ChallengeMode int
+
+const (
+	// ChallengeFailClosed 校验服务不可用时拦截请求
+	ChallengeFailClosed ChallengeMode = iota
+	// ChallengeFailOpen 校验服务不可用时放行请求
+	ChallengeFailOpen
+)
+
+// 人机校验错误的 reason，ErrorEncoder 分别映射为 403、403、503
+const (
+	ChallengeRequiredReason    = "CAPTCHA_REQUIRED"
+	ChallengeFailedReason      = "CAPTCHA_INVALID"
+	ChallengeUnavailableReason = "CAPTCHA_UNAVAILABLE"
+)
+
+var (
+	ErrChallengeRequired    = kerrors.New(403, ChallengeRequiredReason, "captcha required")
+	ErrChallengeFailed      = kerrors.New(403, ChallengeFailedReason, "captcha verification failed")
+	ErrChallengeUnavailable = kerrors.New(503, ChallengeUnavailableReason, "captcha verification unavailable")
+)
+
+// ChallengeVerifier 人机校验 token 的校验器
+// Verify 返回 token 是否有效；err 仅表示校验服务不可用，不代表 token 无效
+type ChallengeVerifier interface {
+	Name() string
+	Verify(ctx context.Context, token, remoteIP string) (bool, error)
+}
+
+// ---------------- siteverify ----------------
+
+// siteVerifier Turnstile、hCaptcha、reCAPTCHA 共用的 siteverify 协议：
+// 以表单 POST secret、response、remoteip，返回 {"success": bool, "error-codes": [...]}
+type siteVerifier struct {
+	name     string
+	endpoint string
+	secret   string
+	client   *http.Client
+	// minScore reCAPTCHA v3 的最低分数，0 表示不校验分数
+	minScore float64
+}
+
+type siteVerifyResponse struct {
+	Success    bool     `json:"success"`
+	Score      *float64 `json:"score,omitempty"`
+	Hostname   string   `json:"hostname"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+// ChallengeVerifierOption siteverify 校验器选项
+type ChallengeVerifierOption func(*siteVerifier)
+
+// WithChallengeEndpoint 自定义 siteverify 地址，测试时可指向 challengetest.Server
+func WithChallengeEndpoint(endpoint string) ChallengeVerifierOption {
+	return func(v *siteVerifier) {
+		v.endpoint = endpoint
+	}
+}
+
+// WithChallengeHTTPClient 自定义 HTTP 客户端，默认超时5秒
+func WithChallengeHTTPClient(client *http.Client) ChallengeVerifierOption {
+	return func(v *siteVerifier) {
+		v.client = client
+	}
+}
+
+// WithChallengeMinScore reCAPTCHA v3 的最低分数（0~1），低于该分数视为校验失败
+func WithChallengeMinScore(score float64) ChallengeVerifierOption {
+	return func(v *siteVerifier) {
+		v.minScore = score
+	}
+}
+
+// NewTurnstileVerifier Cloudflare Turnstile 校验器
+func NewTurnstileVerifier(secret string, opts ...ChallengeVerifierOption) ChallengeVerifier {
+	return newSiteVerifier("turnstile", TurnstileVerifyURL, secret, opts)
+}
+
+// NewHCaptchaVerifier hCaptcha 校验器
+func NewHCaptchaVerifier(secret string, opts ...ChallengeVerifierOption) ChallengeVerifier {
+	return newSiteVerifier("hcaptcha", HCaptchaVerifyURL, secret, opts)
+}
+
+// NewReCaptchaVerifier Google reCAPTCHA 校验器，v3 可通过 WithChallengeMinScore 设置最低分数
+func NewReCaptchaVerifier(secret string, opts ...ChallengeVerifierOption) ChallengeVerifier {
+	return newSiteVerifier("recaptcha", ReCaptchaVerifyURL, secret, opts)
+}
+
+func newSiteVerifier(name, endpoint, secret string, opts []ChallengeVerifierOption) *siteVerifier {
+	v := &siteVerifier{
+		name:     name,
+		endpoint: endpoint,
+		secret:   secret,
+		client:   &http.Client{Timeout: defaultChallengeTimeout},
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+func (v *siteVerifier) Name() string {
+	return v.name
+}
+
+func (v *siteVerifier) Verify(ctx context.Context, token, remoteIP string) (bool, error) {
+	form := url.Values{}
+	form.Set("secret", v.secret)
+	form.Set("response", token)
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return false, errors.Wrapf(err, "%s siteverify", v.name)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return false, errors.Wrapf(err, "%s siteverify read body", v.name)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return false, errors.Errorf("%s siteverify status %d: %s", v.name, resp.StatusCode, body)
+	}
+	var result siteVerifyResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return false, errors.Wrapf(err, "%s siteverify unmarshal", v.name)
+	}
+	if !result.Success {
+		log.Context(ctx).Infow(
+			"msg", "challenge token rejected",
+			"verifier", v.name,
+			"error_codes", strings.Join(result.ErrorCodes, ","),
+		)
+		return false, nil
+	}
+	if v.minScore > 0 && result.Score != nil && *result.Score < v.minScore {
+		log.Context(ctx).Infow(
+			"msg", "challenge score too low",
+			"verifier", v.name,
+			"score", *result.Score,
+		)
+		return false, nil
+	}
+	return true, nil
+}
+
+// ---------------- middleware ----------------
+
+type challengeOptions struct {
+	header                   string
+	mode                     ChallengeMode
+	interceptIfWithoutHeader bool
+	interceptIfVerifyFail    bool
+}
+
+// ChallengeOption 人机校验中间件选项
+type ChallengeOption func(*challengeOptions)
+
+// WithChallengeHeader 读取 token 的请求头，默认 Captcha-Token
+func WithChallengeHeader(header string) ChallengeOption {
+	return func(o *challengeOptions) {
+		o.header = header
+	}
+}
+
+// WithChallengeMode 校验服务不可用时的处理方式，默认 ChallengeFailClosed
+func WithChallengeMode(mode ChallengeMode) ChallengeOption {
+	return func(o *challengeOptions) {
+		o.mode = mode
+	}
+}
+
+// WithChallengeInterceptWithoutHeader 缺少 token 时是否拦截，默认拦截；关闭后仅记录指标，可用于灰度观察
+func WithChallengeInterceptWithoutHeader(intercept bool) ChallengeOption {
+	return func(o *challengeOptions) {
+		o.interceptIfWithoutHeader = intercept
+	}
+}
+
+// WithChallengeInterceptIfVerifyFail token 校验不通过时是否拦截，默认拦截；关闭后仅记录指标
+func WithChallengeInterceptIfVerifyFail(intercept bool) ChallengeOption {
+	return func(o *challengeOptions) {
+		o.interceptIfVerifyFail = intercept
+	}
+}
+
+func newChallengeOptions(opts []ChallengeOption) *challengeOptions {
+	o := &challengeOptions{
+		header:                   ChallengeTokenHeader,
+		mode:                     ChallengeFailClosed,
+		interceptIfWithoutHeader: true,
+		interceptIfVerifyFail:    true,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// NewChallengeMiddleware 只对选定 operation 生效的人机校验中间件，例如：
+//
+//	webkit.NewChallengeMiddleware(webkit.NewTurnstileVerifier(secret)).
+//		Path("/web.Auth/LoginByWallet").
+//		Prefix("/web.Order/").
+//		Build()
+func NewChallengeMiddleware(verifier ChallengeVerifier, opts ...ChallengeOption) *selector.Builder {
+	return selector.Server(ChallengeMiddleware(verifier, opts...))
+}
+
+// ChallengeMiddleware 对所有请求校验人机校验 token，并记录 server_requests_turnstile 与 server_requests_bot_intercept 指标
+func ChallengeMiddleware(verifier ChallengeVerifier, opts ...ChallengeOption) middleware.Middleware {
+	o := newChallengeOptions(opts)
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if err := checkChallenge(ctx, verifier, o); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+// NewChallengeFunc 将校验器转换为拦截规则 captcha 动作使用的 ChallengeFunc
+func NewChallengeFunc(verifier ChallengeVerifier, opts ...ChallengeOption) ChallengeFunc {
+	o := newChallengeOptions(opts)
+	return func(ctx context.Context) bool {
+		token := GetHeader(ctx, o.header)
+		if token == "" {
+			return false
+		}
+		ok, err := verifier.Verify(ctx, token, requestRealIP(ctx))
+		if err != nil {
+			log.Context(ctx).Warnw("msg", "challenge verify failed", "verifier", verifier.Name(), "err", err)
+			return o.mode == ChallengeFailOpen
+		}
+		return ok
+	}
+}
+
+func checkChallenge(ctx context.Context, verifier ChallengeVerifier, o *challengeOptions) (err error) {
+	var (
+		operation     = GetOperationFromContext(ctx)
+		token         = GetHeader(ctx, o.header)
+		verifySuccess = "false"
+	)
+	defer func() {
+		success := strconv.FormatBool(err == nil)
+		blocked := strconv.FormatBool(err != nil)
+		headerExist := strconv.FormatBool(token != "")
+		interceptIfWithoutHeader := strconv.FormatBool(o.interceptIfWithoutHeader)
+		interceptIfVerifyFail := strconv.FormatBool(o.interceptIfVerifyFail)
+		RecordMetricTurnstileWithCtx(ctx, operation, success, headerExist, verifySuccess, interceptIfWithoutHeader, interceptIfVerifyFail)
+		RecordMetricBotInterceptorWithCtx(ctx, operation, blocked, verifier.Name(), success, headerExist, verifySuccess, interceptIfVerifyFail)
+	}()
+
+	if token == "" {
+		if o.interceptIfWithoutHeader {
+			return ErrChallengeRequired
+		}
+		return nil
+	}
+	ok, verr := verifier.Verify(ctx, token, requestRealIP(ctx))
+	if verr != nil {
+		verifySuccess = "error"
+		log.Context(ctx).Warnw("msg", "challenge verify failed", "verifier", verifier.Name(), "err", verr)
+		if o.mode == ChallengeFailOpen {
+			return nil
+		}
+		return ErrChallengeUnavailable
+	}
+	verifySuccess = strconv.FormatBool(ok)
+	if !ok && o.interceptIfVerifyFail {
+		return ErrChallengeFailed
+	}
+	return nil
+}
+
+func (m ChallengeMode) String() string {
+	switch m {
+	case ChallengeFailClosed:
+		return "fail_closed"
+	case ChallengeFailOpen:
+		return "fail_open"
+	}
+	return fmt.Sprintf("ChallengeMode(%d)", int(m))
+}
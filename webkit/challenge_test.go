@@ -0,0 +1,74 @@
+package webkit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+
+	"github.com/seanbit/kratos/webkit/challengetest"
+)
+
+func TestChallengeMiddleware(t *testing.T) {
+	initTestMetrics.Do(func() {
+		if err := InitMetrics("webkit_test"); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	srv := challengetest.NewServer("secret")
+	defer srv.Close()
+	srv.AddToken("ok")
+	srv.AddScoredToken("bot", 0.1)
+
+	tests := []struct {
+		name       string
+		verifier   ChallengeVerifier
+		opts       []ChallengeOption
+		token      string
+		failWith   int
+		wantReason string
+	}{
+		{"valid token", NewTurnstileVerifier("secret", WithChallengeEndpoint(srv.URL)), nil, "ok", 0, ""},
+		{"missing token", NewTurnstileVerifier("secret", WithChallengeEndpoint(srv.URL)), nil, "", 0, "CAPTCHA_REQUIRED"},
+		{"missing token observe only", NewTurnstileVerifier("secret", WithChallengeEndpoint(srv.URL)),
+			[]ChallengeOption{WithChallengeInterceptWithoutHeader(false)}, "", 0, ""},
+		{"invalid token", NewHCaptchaVerifier("secret", WithChallengeEndpoint(srv.URL)), nil, "bad", 0, "CAPTCHA_INVALID"},
+		{"invalid token observe only", NewHCaptchaVerifier("secret", WithChallengeEndpoint(srv.URL)),
+			[]ChallengeOption{WithChallengeInterceptIfVerifyFail(false)}, "bad", 0, ""},
+		{"wrong secret", NewTurnstileVerifier("other", WithChallengeEndpoint(srv.URL)), nil, "ok", 0, "CAPTCHA_INVALID"},
+		{"low score", NewReCaptchaVerifier("secret", WithChallengeEndpoint(srv.URL), WithChallengeMinScore(0.5)), nil, "bot", 0, "CAPTCHA_INVALID"},
+		{"custom header", NewTurnstileVerifier("secret", WithChallengeEndpoint(srv.URL)),
+			[]ChallengeOption{WithChallengeHeader("CF-Turnstile-Response")}, "ok", 0, ""},
+		{"unavailable fail closed", NewTurnstileVerifier("secret", WithChallengeEndpoint(srv.URL)), nil, "ok", 502, "CAPTCHA_UNAVAILABLE"},
+		{"unavailable fail open", NewTurnstileVerifier("secret", WithChallengeEndpoint(srv.URL)),
+			[]ChallengeOption{WithChallengeMode(ChallengeFailOpen)}, "ok", 502, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv.FailWith(tt.failWith)
+			defer srv.FailWith(0)
+
+			header := newChallengeOptions(tt.opts).header
+			req := httptest.NewRequest(http.MethodPost, "/login", nil)
+			if tt.token != "" {
+				req.Header.Set(header, tt.token)
+			}
+			ctx := transport.NewServerContext(context.Background(), &testHTTPTransport{req: req})
+			called := false
+			_, err := ChallengeMiddleware(tt.verifier, tt.opts...)(func(context.Context, interface{}) (interface{}, error) {
+				called = true
+				return "ok", nil
+			})(ctx, nil)
+			if reason := kerrors.Reason(err); err != nil && reason != tt.wantReason || err == nil && tt.wantReason != "" {
+				t.Errorf("err = %v, want reason %q", err, tt.wantReason)
+			}
+			if called != (tt.wantReason == "") {
+				t.Errorf("handler called = %v", called)
+			}
+		})
+	}
+}
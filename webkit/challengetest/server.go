@@ -0,0 +1,103 @@
+// Package challengetest 提供模拟 Turnstile、hCaptcha、reCAPTCHA siteverify 接口的测试服务
+package challengetest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+)
+
+// Server 模拟 siteverify 接口，只有通过 AddToken 登记的 token 校验成功
+//
+//	srv := challengetest.NewServer("secret")
+//	defer srv.Close()
+//	srv.AddToken("ok")
+//	verifier := webkit.NewTurnstileVerifier("secret", webkit.WithChallengeEndpoint(srv.URL))
+type Server struct {
+	*httptest.Server
+
+	secret   string
+	mu       sync.RWMutex
+	tokens   map[string]float64
+	failWith atomic.Int32
+	requests atomic.Int64
+	remoteIP atomic.Value
+}
+
+// NewServer 启动模拟服务，secret 不匹配的请求返回 invalid-input-secret
+func NewServer(secret string) *Server {
+	s := &Server{
+		secret: secret,
+		tokens: make(map[string]float64),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// AddToken 登记有效 token，score 为 1
+func (s *Server) AddToken(token string) {
+	s.AddScoredToken(token, 1)
+}
+
+// AddScoredToken 登记有效 token 及其 reCAPTCHA v3 分数
+func (s *Server) AddScoredToken(token string, score float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[token] = score
+}
+
+// FailWith 之后的请求均返回该 HTTP 状态码，用于模拟校验服务不可用，0 表示恢复正常
+func (s *Server) FailWith(status int) {
+	s.failWith.Store(int32(status))
+}
+
+// Requests 已收到的校验请求数
+func (s *Server) Requests() int64 {
+	return s.requests.Load()
+}
+
+// LastRemoteIP 最近一次校验请求携带的 remoteip
+func (s *Server) LastRemoteIP() string {
+	ip, _ := s.remoteIP.Load().(string)
+	return ip
+}
+
+type verifyResponse struct {
+	Success    bool     `json:"success"`
+	Score      float64  `json:"score,omitempty"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests.Add(1)
+	if status := s.failWith.Load(); status != 0 {
+		w.WriteHeader(int(status))
+		return
+	}
+	if r.Method != http.MethodPost || r.ParseForm() != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.remoteIP.Store(r.PostForm.Get("remoteip"))
+
+	resp := verifyResponse{ErrorCodes: []string{}}
+	token := r.PostForm.Get("response")
+	s.mu.RLock()
+	score, ok := s.tokens[token]
+	s.mu.RUnlock()
+	switch {
+	case r.PostForm.Get("secret") != s.secret:
+		resp.ErrorCodes = append(resp.ErrorCodes, "invalid-input-secret")
+	case token == "":
+		resp.ErrorCodes = append(resp.ErrorCodes, "missing-input-response")
+	case !ok:
+		resp.ErrorCodes = append(resp.ErrorCodes, "invalid-input-response")
+	default:
+		resp.Success = true
+		resp.Score = score
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
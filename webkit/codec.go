@@ -24,6 +24,12 @@ type JsonReply struct {
 func ErrorEncoder(errorReasonValue map[string]int32) khttp.EncodeErrorFunc {
 	errorReasonValue["CODEC"] = 400
 	errorReasonValue["VALIDATOR"] = 400
+	errorReasonValue[RateLimitReason] = 429
+	errorReasonValue[LoadShedReason] = 503
+	errorReasonValue[ChallengeRequiredReason] = 403
+	errorReasonValue[ChallengeFailedReason] = 403
+	errorReasonValue[ChallengeUnavailableReason] = 503
+	errorReasonValue[InterceptBlockReason] = 403
 	return func(w http.ResponseWriter, r *http.Request, err error) {
 		// 尝试从pkg/errors取原始的err，避免向外输出调用栈信息
 		err = errors.Cause(err)
@@ -51,6 +57,9 @@ func ErrorEncoder(errorReasonValue map[string]int32) khttp.EncodeErrorFunc {
 		case 429, 4300:
 			http.Error(w, string(body), http.StatusTooManyRequests)
 			return
+		case 503:
+			http.Error(w, string(body), http.StatusServiceUnavailable)
+			return
 		case 3002:
 			http.Redirect(w, r, reply.Message, http.StatusFound)
 			return
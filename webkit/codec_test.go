@@ -0,0 +1,71 @@
+package webkit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestErrorEncoderChallenge(t *testing.T) {
+	encode := ErrorEncoder(map[string]int32{})
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   int32
+	}{
+		{"required", ErrChallengeRequired, http.StatusForbidden, 403},
+		{"failed", ErrChallengeFailed, http.StatusForbidden, 403},
+		{"unavailable", ErrChallengeUnavailable, http.StatusServiceUnavailable, 503},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			encode(w, httptest.NewRequest(http.MethodPost, "/auth/login", nil), tt.err)
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+			var reply JsonReply
+			if err := json.Unmarshal(w.Body.Bytes(), &reply); err != nil {
+				t.Fatal(err)
+			}
+			if reply.RetCode != tt.code {
+				t.Errorf("code = %d, want %d", reply.RetCode, tt.code)
+			}
+		})
+	}
+}
+
+// 拦截动作默认的错误经 HTTP 编码后不应以 200 返回
+func TestErrorEncoderInterceptActions(t *testing.T) {
+	encode := ErrorEncoder(map[string]int32{})
+	tests := []struct {
+		action RuleAction
+		status int
+		code   int32
+	}{
+		{RuleAction{Type: RuleActionBlock, Code: 403}, http.StatusForbidden, 403},
+		{RuleAction{Type: RuleActionCaptcha}, http.StatusForbidden, 403},
+		{RuleAction{Type: RuleActionRateLimit, Limit: 1, Window: 1}, http.StatusTooManyRequests, 429},
+	}
+	for _, tt := range tests {
+		t.Run(tt.action.Type, func(t *testing.T) {
+			if err := tt.action.validate(); err != nil {
+				t.Fatal(err)
+			}
+			w := httptest.NewRecorder()
+			encode(w, httptest.NewRequest(http.MethodGet, "/probe", nil), tt.action.error())
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+			var reply JsonReply
+			if err := json.Unmarshal(w.Body.Bytes(), &reply); err != nil {
+				t.Fatal(err)
+			}
+			if reply.RetCode != tt.code {
+				t.Errorf("code = %d, want %d", reply.RetCode, tt.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package webkit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+	webasynq "github.com/seanbit/kratos/webkit/transport/asynq"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrEventDecode 事件 payload 无法反序列化，各后端均不再重试：asynq 直接归档，Redis Streams 直接移入死信
+var ErrEventDecode = errors.New("eventbus: decode event")
+
+// EventHandler 处理一条事件的原始 payload，返回错误时由后端决定重试，包装了 ErrEventDecode 的错误不重试
+type EventHandler func(ctx context.Context, payload []byte) error
+
+// EventPublisher 发布领域事件，topic 为 proto.MessageName(msg)
+type EventPublisher interface {
+	Publish(ctx context.Context, msg proto.Message) error
+}
+
+// EventSubscriber 订阅领域事件，须在消费开始（服务 Start）前调用
+type EventSubscriber interface {
+	Subscribe(topic string, handler EventHandler) error
+}
+
+// EventBus 领域事件总线，biz 层只依赖此接口，不关心投递方式
+type EventBus interface {
+	EventPublisher
+	EventSubscriber
+}
+
+// SubscribeEvent 订阅 T 类型事件，payload 自动反序列化为 T
+//
+//	webkit.SubscribeEvent(bus, func(ctx context.Context, e *event.UserLogin) error {
+//		return auth.SaveUserLoginLog(ctx, ...)
+//	})
+func SubscribeEvent[T proto.Message](sub EventSubscriber, fn func(ctx context.Context, msg T) error) error {
+	var zero T
+	msgType := zero.ProtoReflect().Type()
+	topic := string(msgType.Descriptor().FullName())
+	return sub.Subscribe(topic, func(ctx context.Context, payload []byte) error {
+		msg := msgType.New().Interface().(T)
+		if err := proto.Unmarshal(payload, msg); err != nil {
+			return fmt.Errorf("%w %s: %w", ErrEventDecode, topic, err)
+		}
+		return fn(ctx, msg)
+	})
+}
+
+type asynqEventBus struct {
+	client *webasynq.Client
+	router *webasynq.Router
+	opts   []asynq.Option
+
+	mu sync.Mutex
+}
+
+// NewAsynqEventBus 基于 asynq 的事件总线，事件作为任务投递，每个 topic 只能有一个订阅者
+//
+// router 须作为 asynq Server 的 Handler，opts 为每个任务的默认选项，例如 asynq.MaxRetry
+func NewAsynqEventBus(client *webasynq.Client, router *webasynq.Router, opts ...asynq.Option) EventBus {
+	return &asynqEventBus{client: client, router: router, opts: opts}
+}
+
+func (b *asynqEventBus) Publish(ctx context.Context, msg proto.Message) error {
+	_, err := b.client.Publish(ctx, msg, b.opts...)
+	return err
+}
+
+func (b *asynqEventBus) Subscribe(topic string, handler EventHandler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	// 包括已通过 webasynq.Handle 注册的任务类型
+	if b.router.Registered(topic) {
+		return errors.Errorf("eventbus: topic %s already subscribed", topic)
+	}
+	b.router.HandleFunc(topic, func(ctx context.Context, task *asynq.Task) error {
+		err := handler(ctx, webasynq.Payload(task))
+		if errors.Is(err, ErrEventDecode) {
+			return fmt.Errorf("%w: %w", err, asynq.SkipRetry)
+		}
+		return err
+	})
+	return nil
+}
+
+// MemoryEventBus 同步的内存事件总线，Publish 依次调用所有订阅者并返回第一个错误，用于单测
+type MemoryEventBus struct {
+	mu        sync.RWMutex
+	handlers  map[string][]EventHandler
+	published []proto.Message
+}
+
+// NewMemoryEventBus 创建内存事件总线
+func NewMemoryEventBus() *MemoryEventBus {
+	return &MemoryEventBus{handlers: make(map[string][]EventHandler)}
+}
+
+func (b *MemoryEventBus) Publish(ctx context.Context, msg proto.Message) error {
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	topic := string(proto.MessageName(msg))
+	b.mu.Lock()
+	b.published = append(b.published, proto.Clone(msg))
+	handlers := b.handlers[topic]
+	b.mu.Unlock()
+
+	var first error
+	for _, handler := range handlers {
+		if err := handler(ctx, payload); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+func (b *MemoryEventBus) Subscribe(topic string, handler EventHandler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[topic] = append(b.handlers[topic], handler)
+	return nil
+}
+
+// Published 已发布的事件，按发布顺序
+func (b *MemoryEventBus) Published() []proto.Message {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return append([]proto.Message(nil), b.published...)
+}
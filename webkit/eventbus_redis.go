@@ -0,0 +1,189 @@
+package webkit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"github.com/seanbit/kratos/webkit/transport/redisstream"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/protobuf/proto"
+)
+
+// eventStreamPayloadField 消息中 proto payload 的字段名，trace context 与 baggage 以各自字段名写入
+const eventStreamPayloadField = "payload"
+
+var _ transport.Server = (*RedisStreamEventBus)(nil)
+
+// RedisStreamEventBus 基于 Redis Streams 消费组的事件总线，每个 topic 对应一个 stream
+//
+// 消费由 redisstream.Server 完成：同一 group 内的实例分摊消费，不同 group 各自收到全部事件，
+// 处理失败的消息超时后重新领取，多次失败或 payload 无法反序列化时移入死信 stream
+type RedisStreamEventBus struct {
+	cli        redis.UniversalClient
+	group      string
+	prefix     string
+	maxLen     int64
+	serverOpts []redisstream.ServerOption
+
+	mu       sync.Mutex
+	handlers map[string]EventHandler
+	server   *redisstream.Server
+}
+
+// RedisStreamEventBusOption RedisStreamEventBus 选项
+type RedisStreamEventBusOption func(*RedisStreamEventBus)
+
+// WithEventStreamPrefix stream key 前缀，默认 "events:"
+func WithEventStreamPrefix(prefix string) RedisStreamEventBusOption {
+	return func(b *RedisStreamEventBus) {
+		b.prefix = prefix
+	}
+}
+
+// WithEventStreamConsumer 消费者名，默认 hostname-随机串，等同 redisstream.WithConsumer
+func WithEventStreamConsumer(consumer string) RedisStreamEventBusOption {
+	return WithEventStreamServerOptions(redisstream.WithConsumer(consumer))
+}
+
+// WithEventStreamMaxLen stream 近似最大长度，0 表示不裁剪
+func WithEventStreamMaxLen(maxLen int64) RedisStreamEventBusOption {
+	return func(b *RedisStreamEventBus) {
+		b.maxLen = maxLen
+	}
+}
+
+// WithEventStreamServerOptions 消费端选项，例如 redisstream.WithConcurrency、redisstream.WithMaxDeliveries
+func WithEventStreamServerOptions(opts ...redisstream.ServerOption) RedisStreamEventBusOption {
+	return func(b *RedisStreamEventBus) {
+		b.serverOpts = append(b.serverOpts, opts...)
+	}
+}
+
+// WithEventStreamBlock XREADGROUP 阻塞时长，默认5秒，等同 redisstream.WithBlock
+func WithEventStreamBlock(block time.Duration) RedisStreamEventBusOption {
+	return WithEventStreamServerOptions(redisstream.WithBlock(block))
+}
+
+// WithEventStreamMinIdle pending 消息空闲超过该时长后被重新领取，默认1分钟，等同 redisstream.WithMinIdle
+func WithEventStreamMinIdle(minIdle time.Duration) RedisStreamEventBusOption {
+	return WithEventStreamServerOptions(redisstream.WithMinIdle(minIdle))
+}
+
+// WithEventStreamLogger 设置日志，等同 redisstream.WithLogger
+func WithEventStreamLogger(logger log.Logger) RedisStreamEventBusOption {
+	return WithEventStreamServerOptions(redisstream.WithLogger(logger))
+}
+
+// NewRedisStreamEventBus 创建 Redis Streams 事件总线，须作为 kratos.Server 启动才会消费
+func NewRedisStreamEventBus(cli redis.UniversalClient, group string, opts ...RedisStreamEventBusOption) *RedisStreamEventBus {
+	b := &RedisStreamEventBus{
+		cli:      cli,
+		group:    group,
+		prefix:   "events:",
+		handlers: make(map[string]EventHandler),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Publish XADD 到 topic 对应的 stream，trace context 与 baggage 随消息写入
+func (b *RedisStreamEventBus) Publish(ctx context.Context, msg proto.Message) error {
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	values := make(map[string]interface{}, len(carrier)+1)
+	for k, v := range carrier {
+		values[k] = v
+	}
+	values[eventStreamPayloadField] = payload
+	err = b.cli.XAdd(ctx, &redis.XAddArgs{
+		Stream: b.prefix + string(proto.MessageName(msg)),
+		MaxLen: b.maxLen,
+		Approx: b.maxLen > 0,
+		Values: values,
+	}).Err()
+	return errors.Wrap(err, "eventbus: xadd")
+}
+
+// Subscribe 每个 topic 只能有一个订阅者，须在 Start 前调用
+func (b *RedisStreamEventBus) Subscribe(topic string, handler EventHandler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.server != nil {
+		return errors.Errorf("eventbus: subscribe %s after start", topic)
+	}
+	if _, ok := b.handlers[topic]; ok {
+		return errors.Errorf("eventbus: topic %s already subscribed", topic)
+	}
+	b.handlers[topic] = handler
+	return nil
+}
+
+// Start 为已订阅的 topic 启动消费，消费组不存在时从头消费
+func (b *RedisStreamEventBus) Start(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.server != nil || len(b.handlers) == 0 {
+		return nil
+	}
+	streams := make([]string, 0, len(b.handlers))
+	handlers := make(map[string]EventHandler, len(b.handlers))
+	for topic, handler := range b.handlers {
+		streams = append(streams, b.prefix+topic)
+		handlers[b.prefix+topic] = handler
+	}
+	opts := append([]redisstream.ServerOption{
+		redisstream.WithClient(b.cli),
+		redisstream.WithGroup(b.group),
+		redisstream.WithStartID("0"),
+	}, b.serverOpts...)
+	opts = append(opts,
+		redisstream.WithStreams(streams...),
+		redisstream.WithHandler(redisstream.HandlerFunc(func(ctx context.Context, msg *redisstream.Message) error {
+			carrier := propagation.MapCarrier{}
+			for _, field := range otel.GetTextMapPropagator().Fields() {
+				if v := msg.String(field); v != "" {
+					carrier[field] = v
+				}
+			}
+			ctx = otel.GetTextMapPropagator().Extract(ctx, carrier)
+			err := handlers[msg.Stream](ctx, []byte(msg.String(eventStreamPayloadField)))
+			if errors.Is(err, ErrEventDecode) {
+				return fmt.Errorf("%w: %w", err, redisstream.SkipRetry)
+			}
+			return err
+		})),
+	)
+	server := redisstream.NewServer(opts...)
+	if err := server.Start(ctx); err != nil {
+		return err
+	}
+	b.server = server
+	return nil
+}
+
+// Stop 停止消费，等待处理中的消息结束
+func (b *RedisStreamEventBus) Stop(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.server == nil {
+		return nil
+	}
+	if err := b.server.Stop(ctx); err != nil {
+		return err
+	}
+	b.server = nil
+	return nil
+}
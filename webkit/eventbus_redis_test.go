@@ -0,0 +1,193 @@
+package webkit
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// fakeStreamHook 不连接 Redis，记录写命令，XREADGROUP 依次返回 reads 中的消息
+type fakeStreamHook struct {
+	mu       sync.Mutex
+	reads    []redis.XMessage
+	commands [][]interface{}
+	acked    chan string
+}
+
+func (h *fakeStreamHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h *fakeStreamHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+func (h *fakeStreamHook) ProcessHook(redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		h.mu.Lock()
+		h.commands = append(h.commands, cmd.Args())
+		h.mu.Unlock()
+		switch cmd := cmd.(type) {
+		case *redis.XStreamSliceCmd:
+			h.mu.Lock()
+			if len(h.reads) == 0 {
+				h.mu.Unlock()
+				<-ctx.Done()
+				return ctx.Err()
+			}
+			msg := h.reads[0]
+			h.reads = h.reads[1:]
+			h.mu.Unlock()
+			cmd.SetVal([]redis.XStream{{Stream: "events:google.protobuf.StringValue", Messages: []redis.XMessage{msg}}})
+		case *redis.XAutoClaimCmd:
+			cmd.SetVal(nil, "0-0")
+		case *redis.IntCmd:
+			if strings.EqualFold(cmd.Name(), "xack") {
+				h.acked <- cmd.Args()[3].(string)
+			}
+			cmd.SetVal(1)
+		case *redis.StringCmd:
+			cmd.SetVal("1-0")
+		case *redis.StatusCmd:
+			cmd.SetVal("OK")
+		}
+		return nil
+	}
+}
+
+// command 返回第一条 name 命令的参数
+func (h *fakeStreamHook) command(name string) []interface{} {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, args := range h.commands {
+		if strings.EqualFold(args[0].(string), name) {
+			return args
+		}
+	}
+	return nil
+}
+
+func newFakeStreamClient(hook *fakeStreamHook) *redis.Client {
+	cli := redis.NewClient(&redis.Options{
+		Dialer: func(context.Context, string, string) (net.Conn, error) {
+			return nil, net.ErrClosed
+		},
+	})
+	cli.AddHook(hook)
+	return cli
+}
+
+func TestRedisStreamEventBus(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	hook := &fakeStreamHook{acked: make(chan string, 2)}
+	cli := newFakeStreamClient(hook)
+	defer cli.Close()
+	bus := NewRedisStreamEventBus(cli, "g", WithEventStreamConsumer("c1"), WithEventStreamMaxLen(100))
+
+	// Publish 写入 payload 与 trace context
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	}))
+	if err := bus.Publish(ctx, wrapperspb.String("hello")); err != nil {
+		t.Fatal(err)
+	}
+	args := hook.command("xadd")
+	if args == nil || args[1] != "events:google.protobuf.StringValue" {
+		t.Fatalf("xadd = %v", args)
+	}
+	values := map[string]interface{}{}
+	for i, arg := range args {
+		if arg != "*" {
+			continue
+		}
+		for j := i + 1; j+1 < len(args); j += 2 {
+			values[args[j].(string)] = args[j+1]
+		}
+		break
+	}
+	payload, _ := proto.Marshal(wrapperspb.String("hello"))
+	if string(values[eventStreamPayloadField].([]byte)) != string(payload) {
+		t.Errorf("payload = %v", values[eventStreamPayloadField])
+	}
+	traceparent, _ := values["traceparent"].(string)
+	if !strings.Contains(traceparent, traceID.String()) {
+		t.Errorf("traceparent = %q", traceparent)
+	}
+
+	// Start 消费时还原 trace context，无法反序列化的消息移入死信
+	hook.reads = []redis.XMessage{
+		{ID: "1-0", Values: map[string]interface{}{eventStreamPayloadField: string(payload), "traceparent": traceparent}},
+		{ID: "2-0", Values: map[string]interface{}{eventStreamPayloadField: "\xff"}},
+	}
+	type received struct {
+		value   string
+		traceID trace.TraceID
+	}
+	got := make(chan received, 1)
+	err := SubscribeEvent(bus, func(ctx context.Context, msg *wrapperspb.StringValue) error {
+		got <- received{msg.GetValue(), trace.SpanContextFromContext(ctx).TraceID()}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := bus.Stop(context.Background()); err != nil {
+			t.Error(err)
+		}
+	}()
+	if err := bus.Subscribe("late", nil); err == nil {
+		t.Error("subscribe after start should fail")
+	}
+
+	acked := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		select {
+		case id := <-hook.acked:
+			acked[id] = true
+		case <-time.After(time.Second):
+			t.Fatal("message not acked")
+		}
+	}
+	if r := <-got; r.value != "hello" || r.traceID != traceID {
+		t.Errorf("received %q with trace %s", r.value, r.traceID)
+	}
+	if !acked["1-0"] || !acked["2-0"] {
+		t.Errorf("acked = %v", acked)
+	}
+	if args := hook.command("xgroup"); args == nil || args[2] != "events:google.protobuf.StringValue" || args[3] != "g" {
+		t.Errorf("xgroup = %v", args)
+	}
+	if args := hook.command("xreadgroup"); args == nil || args[3] != "c1" {
+		t.Errorf("xreadgroup = %v", args)
+	}
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	var dead bool
+	for _, args := range hook.commands {
+		if strings.EqualFold(args[0].(string), "xadd") && args[1] == "events:google.protobuf.StringValue:dead" {
+			dead = true
+		}
+	}
+	if !dead {
+		t.Error("undecodable message not moved to dead letter stream")
+	}
+}
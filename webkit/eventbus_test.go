@@ -0,0 +1,82 @@
+package webkit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	webasynq "github.com/seanbit/kratos/webkit/transport/asynq"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestMemoryEventBus(t *testing.T) {
+	bus := NewMemoryEventBus()
+	var got []string
+	for i := 0; i < 2; i++ {
+		err := SubscribeEvent(bus, func(ctx context.Context, msg *wrapperspb.StringValue) error {
+			got = append(got, msg.GetValue())
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	failed := errors.New("failed")
+	_ = SubscribeEvent(bus, func(ctx context.Context, msg *wrapperspb.Int64Value) error {
+		return failed
+	})
+
+	if err := bus.Publish(context.Background(), wrapperspb.String("hello")); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0] != "hello" || got[1] != "hello" {
+		t.Errorf("got %v, want two deliveries", got)
+	}
+	if err := bus.Publish(context.Background(), wrapperspb.Int64(1)); !errors.Is(err, failed) {
+		t.Errorf("err = %v, want %v", err, failed)
+	}
+	if err := bus.Publish(context.Background(), wrapperspb.Bool(true)); err != nil {
+		t.Errorf("publish without subscriber: %v", err)
+	}
+	if n := len(bus.Published()); n != 3 {
+		t.Errorf("published %d, want 3", n)
+	}
+}
+
+func TestAsynqEventBusSubscribe(t *testing.T) {
+	router := webasynq.NewRouter(webasynq.WithUnknownTaskPolicy(webasynq.UnknownTaskError))
+	bus := NewAsynqEventBus(nil, router)
+	var got string
+	err := SubscribeEvent(bus, func(ctx context.Context, msg *wrapperspb.StringValue) error {
+		got = msg.GetValue()
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SubscribeEvent(bus, func(context.Context, *wrapperspb.StringValue) error { return nil }); err == nil {
+		t.Error("duplicate subscription should fail")
+	}
+
+	payload, _ := proto.Marshal(wrapperspb.String("hello"))
+	if err := router.ProcessTask(context.Background(), asynq.NewTask("google.protobuf.StringValue", payload)); err != nil {
+		t.Fatal(err)
+	}
+	if got != "hello" {
+		t.Errorf("got %q, want %q", got, "hello")
+	}
+
+	// payload 无法反序列化时不重试
+	err = router.ProcessTask(context.Background(), asynq.NewTask("google.protobuf.StringValue", []byte{0xff}))
+	if !errors.Is(err, ErrEventDecode) || !errors.Is(err, asynq.SkipRetry) {
+		t.Errorf("err = %v, want ErrEventDecode and SkipRetry", err)
+	}
+
+	// 已通过 Handle 注册的任务类型返回错误而不是 panic
+	webasynq.Handle(router, func(context.Context, *wrapperspb.Int64Value) error { return nil })
+	if err := SubscribeEvent(bus, func(context.Context, *wrapperspb.Int64Value) error { return nil }); err == nil {
+		t.Error("subscribing a handled task type should fail")
+	}
+}
@@ -0,0 +1,294 @@
+package webkit
+
+import (
+	"context"
+	"database/sql"
+	"os"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"github.com/shirou/gopsutil/v3/process"
+	"go.opentelemetry.io/contrib/instrumentation/runtime"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+// 以下注册函数均绑定调用时的 Meter，须在 InitMetrics 之后调用；
+// 返回的 unregister 用于连接池关闭时停止采集，ShutdownMetrics 后自动失效
+
+// RegisterRuntimeMetrics 注册 Go runtime（go.*）与进程（process_*）指标
+//
+// 启用 prometheus 导出时，进程 CPU 时间与文件描述符数由默认 registry 的 ProcessCollector 导出，这里不重复注册，
+// 否则同名指标的 help 不一致，/metrics 会返回 500；仅使用 OTLP 等其他导出方式时由这里注册
+func RegisterRuntimeMetrics() error {
+	var provider metric.MeterProvider = noop.NewMeterProvider()
+	if meterProvider != nil {
+		provider = meterProvider
+	}
+	if err := runtime.Start(runtime.WithMeterProvider(provider)); err != nil {
+		return errors.Wrap(err, "start runtime metrics")
+	}
+	_, err := registerProcessMetrics()
+	return err
+}
+
+func registerProcessMetrics() (func(), error) {
+	proc, err := process.NewProcess(int32(os.Getpid()))
+	if err != nil {
+		return nil, errors.Wrap(err, "process metrics")
+	}
+	memory, err := meter.Int64ObservableGauge("process_memory_bytes",
+		metric.WithDescription("进程内存占用，type=rss|vms"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	threads, err := meter.Int64ObservableGauge("process_threads",
+		metric.WithDescription("进程线程数"),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	instruments := []metric.Observable{memory, threads}
+	var cpu metric.Float64ObservableCounter
+	var fds metric.Int64ObservableGauge
+	if !metricsPrometheus {
+		cpu, err = meter.Float64ObservableCounter("process_cpu_seconds_total",
+			metric.WithDescription("进程累计 CPU 时间"),
+			metric.WithUnit("s"),
+		)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		fds, err = meter.Int64ObservableGauge("process_open_fds",
+			metric.WithDescription("进程打开的文件描述符数"),
+		)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		instruments = append(instruments, cpu, fds)
+	}
+
+	return registerCallback(func(ctx context.Context, o metric.Observer) error {
+		if cpu != nil {
+			if times, err := proc.TimesWithContext(ctx); err == nil {
+				o.ObserveFloat64(cpu, times.User+times.System)
+			}
+			if n, err := proc.NumFDsWithContext(ctx); err == nil {
+				o.ObserveInt64(fds, int64(n))
+			}
+		}
+		if mem, err := proc.MemoryInfoWithContext(ctx); err == nil {
+			o.ObserveInt64(memory, int64(mem.RSS), metric.WithAttributes(attribute.String("type", "rss")))
+			o.ObserveInt64(memory, int64(mem.VMS), metric.WithAttributes(attribute.String("type", "vms")))
+		}
+		if n, err := proc.NumThreadsWithContext(ctx); err == nil {
+			o.ObserveInt64(threads, int64(n))
+		}
+		return nil
+	}, instruments...)
+}
+
+// RegisterDBStatsMetrics 注册 database/sql 连接池指标，name 区分不同连接池（如 master、replica）
+//
+//	sqlDB, _ := gormDB.DB()
+//	unregister, err := webkit.RegisterDBStatsMetrics("master", sqlDB)
+func RegisterDBStatsMetrics(name string, db *sql.DB) (func(), error) {
+	if db == nil {
+		return nil, errors.New("register db stats metrics: nil db")
+	}
+	connections, err := meter.Int64ObservableGauge("db_pool_connections",
+		metric.WithDescription("连接池连接数，state=in_use|idle"),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	maxOpen, err := meter.Int64ObservableGauge("db_pool_max_open_connections",
+		metric.WithDescription("连接池最大连接数，0 表示不限制"),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	waitCount, err := meter.Int64ObservableCounter("db_pool_wait_total",
+		metric.WithDescription("等待空闲连接的累计次数"),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	waitDuration, err := meter.Float64ObservableCounter("db_pool_wait_seconds_total",
+		metric.WithDescription("等待空闲连接的累计耗时"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	closed, err := meter.Int64ObservableCounter("db_pool_closed_total",
+		metric.WithDescription("连接池主动关闭的连接数，reason=max_idle|max_idle_time|max_lifetime"),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	pool := attribute.String("pool", name)
+	return registerCallback(func(_ context.Context, o metric.Observer) error {
+		stats := db.Stats()
+		o.ObserveInt64(connections, int64(stats.InUse), metric.WithAttributes(pool, attribute.String("state", "in_use")))
+		o.ObserveInt64(connections, int64(stats.Idle), metric.WithAttributes(pool, attribute.String("state", "idle")))
+		o.ObserveInt64(maxOpen, int64(stats.MaxOpenConnections), metric.WithAttributes(pool))
+		o.ObserveInt64(waitCount, stats.WaitCount, metric.WithAttributes(pool))
+		o.ObserveFloat64(waitDuration, stats.WaitDuration.Seconds(), metric.WithAttributes(pool))
+		o.ObserveInt64(closed, stats.MaxIdleClosed, metric.WithAttributes(pool, attribute.String("reason", "max_idle")))
+		o.ObserveInt64(closed, stats.MaxIdleTimeClosed, metric.WithAttributes(pool, attribute.String("reason", "max_idle_time")))
+		o.ObserveInt64(closed, stats.MaxLifetimeClosed, metric.WithAttributes(pool, attribute.String("reason", "max_lifetime")))
+		return nil
+	}, connections, maxOpen, waitCount, waitDuration, closed)
+}
+
+// RedisPoolStater 提供连接池统计的 redis 客户端，redis.UniversalClient 均满足
+type RedisPoolStater interface {
+	PoolStats() *redis.PoolStats
+}
+
+// RegisterRedisPoolMetrics 注册 go-redis 连接池指标，name 区分不同客户端
+func RegisterRedisPoolMetrics(name string, cli RedisPoolStater) (func(), error) {
+	if cli == nil {
+		return nil, errors.New("register redis pool metrics: nil client")
+	}
+	connections, err := meter.Int64ObservableGauge("redis_pool_connections",
+		metric.WithDescription("连接池连接数，state=total|idle"),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	requests, err := meter.Int64ObservableCounter("redis_pool_requests_total",
+		metric.WithDescription("从连接池获取连接的累计次数，result=hit|miss|timeout"),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	stale, err := meter.Int64ObservableCounter("redis_pool_stale_total",
+		metric.WithDescription("连接池移除的过期连接数"),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	waitCount, err := meter.Int64ObservableCounter("redis_pool_wait_total",
+		metric.WithDescription("等待空闲连接的累计次数"),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	pool := attribute.String("pool", name)
+	return registerCallback(func(_ context.Context, o metric.Observer) error {
+		stats := cli.PoolStats()
+		if stats == nil {
+			return nil
+		}
+		o.ObserveInt64(connections, int64(stats.TotalConns), metric.WithAttributes(pool, attribute.String("state", "total")))
+		o.ObserveInt64(connections, int64(stats.IdleConns), metric.WithAttributes(pool, attribute.String("state", "idle")))
+		o.ObserveInt64(requests, int64(stats.Hits), metric.WithAttributes(pool, attribute.String("result", "hit")))
+		o.ObserveInt64(requests, int64(stats.Misses), metric.WithAttributes(pool, attribute.String("result", "miss")))
+		o.ObserveInt64(requests, int64(stats.Timeouts), metric.WithAttributes(pool, attribute.String("result", "timeout")))
+		o.ObserveInt64(stale, int64(stats.StaleConns), metric.WithAttributes(pool))
+		o.ObserveInt64(waitCount, int64(stats.WaitCount), metric.WithAttributes(pool))
+		return nil
+	}, connections, requests, stale, waitCount)
+}
+
+// AsynqQueueInspector 读取 asynq 队列信息，*asynq.Inspector 满足
+type AsynqQueueInspector interface {
+	Queues() ([]string, error)
+	GetQueueInfo(queue string) (*asynq.QueueInfo, error)
+}
+
+// RegisterAsynqQueueMetrics 注册 asynq 队列深度、延迟与重试/归档数指标，每次采集时查询 redis
+func RegisterAsynqQueueMetrics(inspector AsynqQueueInspector) (func(), error) {
+	if inspector == nil {
+		return nil, errors.New("register asynq queue metrics: nil inspector")
+	}
+	tasks, err := meter.Int64ObservableGauge("asynq_queue_tasks",
+		metric.WithDescription("队列任务数，state=pending|active|scheduled|retry|archived|completed|aggregating"),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	size, err := meter.Int64ObservableGauge("asynq_queue_size",
+		metric.WithDescription("队列任务总数"),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	latency, err := meter.Float64ObservableGauge("asynq_queue_latency_seconds",
+		metric.WithDescription("队列延迟，即最早 pending 任务的等待时间"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	paused, err := meter.Int64ObservableGauge("asynq_queue_paused",
+		metric.WithDescription("队列是否暂停，1 表示暂停"),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	processed, err := meter.Int64ObservableCounter("asynq_queue_processed_total",
+		metric.WithDescription("队列累计处理的任务数，result=all|failed"),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return registerCallback(func(_ context.Context, o metric.Observer) error {
+		queues, err := inspector.Queues()
+		if err != nil {
+			log.Warnf("asynq queue metrics: list queues: %+v", err)
+			return nil
+		}
+		for _, queue := range queues {
+			info, err := inspector.GetQueueInfo(queue)
+			if err != nil {
+				log.Warnf("asynq queue metrics: queue %s: %+v", queue, err)
+				continue
+			}
+			q := attribute.String("queue", queue)
+			for state, n := range map[string]int{
+				"pending":     info.Pending,
+				"active":      info.Active,
+				"scheduled":   info.Scheduled,
+				"retry":       info.Retry,
+				"archived":    info.Archived,
+				"completed":   info.Completed,
+				"aggregating": info.Aggregating,
+			} {
+				o.ObserveInt64(tasks, int64(n), metric.WithAttributes(q, attribute.String("state", state)))
+			}
+			o.ObserveInt64(size, int64(info.Size), metric.WithAttributes(q))
+			o.ObserveFloat64(latency, info.Latency.Seconds(), metric.WithAttributes(q))
+			var p int64
+			if info.Paused {
+				p = 1
+			}
+			o.ObserveInt64(paused, p, metric.WithAttributes(q))
+			o.ObserveInt64(processed, int64(info.ProcessedTotal), metric.WithAttributes(q, attribute.String("result", "all")))
+			o.ObserveInt64(processed, int64(info.FailedTotal), metric.WithAttributes(q, attribute.String("result", "failed")))
+		}
+		return nil
+	}, tasks, size, latency, paused, processed)
+}
+
+func registerCallback(f metric.Callback, instruments ...metric.Observable) (func(), error) {
+	reg, err := meter.RegisterCallback(f, instruments...)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return func() {
+		if err := reg.Unregister(); err != nil {
+			log.Warnf("unregister metrics callback: %+v", err)
+		}
+	}, nil
+}
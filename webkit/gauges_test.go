@@ -0,0 +1,77 @@
+package webkit
+
+import (
+	"context"
+	"testing"
+
+	promclient "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// 与 Prometheus 默认 registry 的 Go、进程指标共存时 Gather 不应报错
+func TestRuntimeMetricsWithDefaultCollectors(t *testing.T) {
+	registry := promclient.NewRegistry()
+	registry.MustRegister(
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		collectors.NewGoCollector(),
+	)
+	if err := InitMetrics("webkit_test", WithMetricsPrometheusRegisterer(registry)); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := ShutdownMetrics(context.Background()); err != nil {
+			t.Error(err)
+		}
+	}()
+	if err := RegisterRuntimeMetrics(); err != nil {
+		t.Fatal(err)
+	}
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("gather: %v", err)
+	}
+	found := map[string]bool{}
+	for _, family := range families {
+		found[family.GetName()] = true
+	}
+	for _, name := range []string{"process_cpu_seconds_total", "process_memory_bytes", "process_threads"} {
+		if !found[name] {
+			t.Errorf("metric %s not gathered", name)
+		}
+	}
+}
+
+// 未启用 prometheus 导出时由 RegisterRuntimeMetrics 导出进程 CPU 时间与文件描述符数
+func TestRuntimeMetricsWithoutPrometheus(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	if err := InitMetrics("webkit_test", WithMetricsExporters(), WithMetricsReader(reader)); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := ShutdownMetrics(context.Background()); err != nil {
+			t.Error(err)
+		}
+	}()
+	if err := RegisterRuntimeMetrics(); err != nil {
+		t.Fatal(err)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	found := map[string]bool{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			found[m.Name] = true
+		}
+	}
+	for _, name := range []string{"process_cpu_seconds_total", "process_open_fds", "process_memory_bytes", "process_threads"} {
+		if !found[name] {
+			t.Errorf("metric %s not collected", name)
+		}
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"math/rand"
 	"net/http"
@@ -16,8 +15,6 @@ import (
 	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
-	"github.com/go-kratos/kratos/v2/transport"
-	khttp "github.com/go-kratos/kratos/v2/transport/http"
 	"github.com/pkg/errors"
 
 	"github.com/go-kratos/kratos/v2/middleware"
@@ -33,33 +30,37 @@ const (
 var (
 	once sync.Once
 
-	currentServerName string
-
-	interceptRedisCli redis.UniversalClient
-
-	// interceptConf 使用 atomic.Value 保证并发安全
-	// 在 goroutine 中定期更新配置，在请求处理中读取配置
-	interceptConf atomic.Value // 存储 *InterceptConfig
-
-	interceptionKey string
-
-	// 签名配置
-	signConfig atomic.Value // 存储 *SignConfig
-
-	// interceptCancel 用于优雅关闭配置刷新 goroutine
-	interceptCancel context.CancelFunc
+	// defaultInterceptor 包级函数使用的默认实例，InitInterceptConfig 前为未启用的空实例
+	defaultInterceptor atomic.Pointer[TrafficInterceptor]
 )
 
+func init() {
+	ti, _ := NewTrafficInterceptor()
+	defaultInterceptor.Store(ti)
+}
+
 // SignConfig 签名验证配置
 type SignConfig struct {
-	// Secret HMAC密钥，为空则禁用签名验证
+	// Secret HMAC密钥，等同于 kid 为 default 的密钥；与 Keys 均为空则禁用签名验证
 	Secret string
+	// Keys 签名密钥环，多个密钥同时参与验证，用于平滑轮换
+	Keys []SignKey
+	// SigningKid 用于签名的密钥 ID，为空时取第一个生效中的密钥
+	SigningKid string
 	// SignatureLength 签名长度（hex字符数），默认8
 	SignatureLength int
 	// MaxTimeDrift 最大时间偏差（秒），防止重放攻击，默认300秒（5分钟）
 	MaxTimeDrift int64
 	// Enabled 是否启用签名验证
 	Enabled bool
+	// RejectV1 拒绝仅签名时间戳的 v1 格式，客户端全部迁移到 v2 后开启
+	RejectV1 bool
+	// MaxBodySize v2 签名校验读取的最大请求体字节数，超过时校验失败，默认1MB
+	MaxBodySize int64
+
+	// keys/ring 由 normalize 生成的密钥环
+	keys []SignKey
+	ring map[string]*SignKey
 }
 
 // DefaultSignConfig 返回默认签名配置
@@ -76,171 +77,404 @@ type InterceptConfig struct {
 	SubRules []SubRuleConfig `json:"sub_rules"`
 	Radio    int             `json:"radio"`
 	Switch   bool            `json:"switch"`
+	// StickyKey 全局 radio 的粘性采样 key，取值同 SubRuleConfig.StickyKey
+	StickyKey string `json:"sticky_key,omitempty"`
+
+	// compiled/global 加载时编译好的子规则与全局规则，见 Compile
+	compiled []*compiledSubRule
+	global   *compiledSubRule
 }
 
 type SubRuleConfig struct {
+	// Name 规则名称，用于日志与指标，默认 rule_{下标}
+	Name  string `json:"name,omitempty"`
 	Path  string `json:"path"`
 	Rule  string `json:"rule"`
 	Value string `json:"value"`
 	Radio int    `json:"radio"`
+	// Match 组合匹配表达式，设置后忽略 Rule/Value
+	Match *RuleExpr `json:"match,omitempty"`
+	// Action 命中后的处置动作，未设置时按旧规则：radio 0 告警，1~100 按比例拦截
+	Action *RuleAction `json:"action,omitempty"`
+	// StickyKey 按比例采样时的一致性哈希 key：uid、ip、device_id、header:{name}
+	// 默认 uid，未登录时回退为 ip，同一客户端总是落在比例之内或之外
+	StickyKey string `json:"sticky_key,omitempty"`
 }
 
-// InitInterceptConfig 初始化流量拦截配置
-// serverName: 服务名称
-// redisCli: Redis客户端
-// signCfg: 签名配置，传nil使用默认配置（禁用签名验证）
-func InitInterceptConfig(serverName string, redisCli redis.UniversalClient, signCfg *SignConfig) {
-	once.Do(func() {
-		if redisCli == nil {
-			panic("init intercept redis client failed:invalid client")
-		}
-		currentServerName = serverName
-		interceptionKey = fmt.Sprintf(InterceptionKeyPrefix, serverName)
-		interceptRedisCli = redisCli
+// TrafficInterceptor 流量拦截器，持有各自的拦截配置、配置来源、签名配置、时钟与随机源
+// 同一进程内可以创建多个配置不同的实例
+type TrafficInterceptor struct {
+	serverName string
+	redisCli   redis.UniversalClient
+	sources    []InterceptConfigSource
+
+	// conf 由配置来源在 goroutine 中更新，在请求处理中读取
+	conf     atomic.Pointer[InterceptConfig]
+	signConf atomic.Pointer[SignConfig]
+	// countryResolver 规则中 country 字段使用的 IP 国家解析器
+	countryResolver atomic.Value // 存储 CountryResolver
+
+	now      func() time.Time
+	randMu   sync.Mutex
+	rand     *rand.Rand
+	hashSeed uint64
+
+	notifier     InterceptNotifier
+	challenge    ChallengeFunc
+	notifyMu     sync.Mutex
+	notifyAt     map[string]time.Time
+	localCounter windowCounter
+
+	cancel   context.CancelFunc
+	stopOnce sync.Once
+}
 
-		// 初始化签名配置
-		if signCfg == nil {
-			signCfg = DefaultSignConfig()
-		}
-		if signCfg.SignatureLength <= 0 {
-			signCfg.SignatureLength = 8
+// TrafficInterceptorOption 拦截器选项
+type TrafficInterceptorOption func(*TrafficInterceptor)
+
+// WithInterceptServerName 服务名称，用于指标与 Redis key
+func WithInterceptServerName(serverName string) TrafficInterceptorOption {
+	return func(ti *TrafficInterceptor) {
+		ti.serverName = serverName
+	}
+}
+
+// WithInterceptRedis Redis 客户端，用于默认配置来源与签名 nonce 去重
+func WithInterceptRedis(cli redis.UniversalClient) TrafficInterceptorOption {
+	return func(ti *TrafficInterceptor) {
+		ti.redisCli = cli
+	}
+}
+
+// WithInterceptSources 拦截配置来源，不设置时若有 Redis 客户端则回退为每3秒轮询 Redis
+func WithInterceptSources(sources ...InterceptConfigSource) TrafficInterceptorOption {
+	return func(ti *TrafficInterceptor) {
+		ti.sources = append(ti.sources, sources...)
+	}
+}
+
+// WithInterceptConfig 初始拦截配置，配合无来源的实例可用于静态配置或单元测试
+func WithInterceptConfig(conf *InterceptConfig) TrafficInterceptorOption {
+	return func(ti *TrafficInterceptor) {
+		ti.conf.Store(conf)
+	}
+}
+
+// WithInterceptSignConfig 签名配置，不设置时禁用签名验证
+func WithInterceptSignConfig(cfg *SignConfig) TrafficInterceptorOption {
+	return func(ti *TrafficInterceptor) {
+		ti.signConf.Store(cfg)
+	}
+}
+
+// WithInterceptCountryResolver IP 国家解析器，如 *GeoIP
+func WithInterceptCountryResolver(resolver CountryResolver) TrafficInterceptorOption {
+	return func(ti *TrafficInterceptor) {
+		if resolver != nil {
+			ti.countryResolver.Store(resolver)
 		}
-		if signCfg.MaxTimeDrift <= 0 {
-			signCfg.MaxTimeDrift = 300
+	}
+}
+
+// WithInterceptClock 时钟，默认 time.Now
+func WithInterceptClock(now func() time.Time) TrafficInterceptorOption {
+	return func(ti *TrafficInterceptor) {
+		ti.now = now
+	}
+}
+
+// WithInterceptRandSource 随机源，用于按比例拦截，默认以当前时间为种子
+func WithInterceptRandSource(src rand.Source) TrafficInterceptorOption {
+	return func(ti *TrafficInterceptor) {
+		ti.rand = rand.New(src)
+	}
+}
+
+// NewTrafficInterceptor 创建流量拦截器
+// 同步加载各配置来源的当前配置，随后在后台监听变更，直到调用 Stop
+func NewTrafficInterceptor(opts ...TrafficInterceptorOption) (*TrafficInterceptor, error) {
+	ti := &TrafficInterceptor{
+		now: time.Now,
+	}
+	for _, opt := range opts {
+		opt(ti)
+	}
+	if ti.rand == nil {
+		ti.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	signCfg := ti.signConf.Load()
+	if signCfg == nil {
+		signCfg = DefaultSignConfig()
+	}
+	if err := signCfg.normalize(); err != nil {
+		return nil, errors.Wrap(err, "invalid sign config")
+	}
+	ti.signConf.Store(signCfg)
+
+	if conf := ti.conf.Load(); conf != nil {
+		if err := conf.Compile(); err != nil {
+			return nil, errors.Wrap(err, "invalid interception config")
 		}
-		signConfig.Store(signCfg)
+	}
 
-		// 创建可取消的 context 用于控制 goroutine 生命周期
-		ctx, cancel := context.WithCancel(context.Background())
-		interceptCancel = cancel
+	if len(ti.sources) == 0 && ti.redisCli != nil {
+		key := fmt.Sprintf(InterceptionKeyPrefix, ti.serverName)
+		ti.sources = []InterceptConfigSource{NewRedisPollInterceptSource(ti.redisCli, key, interceptPollInterval)}
+	}
+	if len(ti.sources) == 0 {
+		return ti, nil
+	}
 
-		time.Sleep(time.Duration(rand.Intn(2000)) * time.Millisecond)
-		loadInterceptConfig(ctx)
-		go func() {
+	// 创建可取消的 context 用于控制 goroutine 生命周期
+	ctx, cancel := context.WithCancel(context.Background())
+	ti.cancel = cancel
+	for _, source := range ti.sources {
+		conf, err := source.Load(ctx)
+		if err != nil {
+			log.Warnf("load interception config failed:%v", err)
+			continue
+		}
+		ti.storeConfig(conf)
+	}
+	for _, source := range ti.sources {
+		go ti.watchSource(ctx, source)
+	}
+	return ti, nil
+}
+
+// watchSource 监听配置来源，异常退出后延迟重试直到 ctx 取消
+func (ti *TrafficInterceptor) watchSource(ctx context.Context, source InterceptConfigSource) {
+	for {
+		func() {
 			defer func() {
 				if r := recover(); r != nil {
-					log.Errorf("reload interception config panic:%v", r)
+					log.Errorf("watch interception config panic:%v", r)
 				}
 			}()
-			ticker := time.NewTicker(3 * time.Second)
-			defer ticker.Stop()
-			for {
-				select {
-				case <-ctx.Done():
-					log.Info("intercept config reload goroutine stopped")
-					return
-				case <-ticker.C:
-					loadInterceptConfig(ctx)
-				}
+			if err := source.Watch(ctx, ti.storeConfig); err != nil {
+				log.Warnf("watch interception config failed:%v", err)
 			}
 		}()
+		select {
+		case <-ctx.Done():
+			log.Info("intercept config reload goroutine stopped")
+			return
+		case <-time.After(interceptPollInterval):
+		}
+	}
+}
+
+// Stop 停止配置刷新 goroutine（用于优雅关闭）
+func (ti *TrafficInterceptor) Stop() {
+	ti.stopOnce.Do(func() {
+		if ti.cancel != nil {
+			ti.cancel()
+		}
 	})
 }
 
-// StopInterceptConfig 停止配置刷新 goroutine（用于优雅关闭）
-func StopInterceptConfig() {
-	if interceptCancel != nil {
-		interceptCancel()
+// SetConfig 编译并替换拦截配置，编译失败时保留旧配置
+func (ti *TrafficInterceptor) SetConfig(conf *InterceptConfig) error {
+	if conf == nil {
+		return nil
 	}
+	if err := conf.Compile(); err != nil {
+		return err
+	}
+	ti.conf.Store(conf)
+	return nil
 }
 
-// UpdateSignConfig 动态更新签名配置
-func UpdateSignConfig(signCfg *SignConfig) {
-	if signCfg == nil {
-		return
+// storeConfig 供配置来源回调，编译失败时记录日志并保留旧配置
+func (ti *TrafficInterceptor) storeConfig(conf *InterceptConfig) {
+	if err := ti.SetConfig(conf); err != nil {
+		log.Errorf("compile interception config failed, keep previous config:%v", err)
 	}
-	if signCfg.SignatureLength <= 0 {
-		signCfg.SignatureLength = 8
+}
+
+// Config 当前生效的拦截配置，未加载时返回 nil
+func (ti *TrafficInterceptor) Config() *InterceptConfig {
+	return ti.conf.Load()
+}
+
+// UpdateSignConfig 动态更新签名配置，整体替换密钥环
+// 密钥环校验失败时保留旧配置并返回错误
+func (ti *TrafficInterceptor) UpdateSignConfig(signCfg *SignConfig) error {
+	if signCfg == nil {
+		return nil
 	}
-	if signCfg.MaxTimeDrift <= 0 {
-		signCfg.MaxTimeDrift = 300
+	if err := signCfg.normalize(); err != nil {
+		return err
 	}
-	signConfig.Store(signCfg)
+	ti.signConf.Store(signCfg)
+	return nil
 }
 
-// getSignConfig 安全地获取签名配置
-func getSignConfig() *SignConfig {
-	if v := signConfig.Load(); v != nil {
-		return v.(*SignConfig)
+func (ti *TrafficInterceptor) signConfig() *SignConfig {
+	if cfg := ti.signConf.Load(); cfg != nil {
+		return cfg
 	}
 	return DefaultSignConfig()
 }
 
-func loadInterceptConfig(ctx context.Context) {
-	data, err := interceptRedisCli.Get(ctx, interceptionKey).Result()
-	if err != nil {
-		log.Warnf("reload interception config failed:%v", err)
-		return
-	}
-	var conf InterceptConfig
-	err = json.Unmarshal([]byte(data), &conf)
-	if err != nil {
-		log.Warnf("unmarshal interception config failed:%v, data:%v", err, data)
+// SetNotifier 设置 notify 动作使用的告警通道
+func (ti *TrafficInterceptor) SetNotifier(notifier InterceptNotifier) {
+	ti.notifyMu.Lock()
+	defer ti.notifyMu.Unlock()
+	ti.notifier = notifier
+}
+
+// SetCountryResolver 设置规则中 country 字段使用的 IP 国家解析器
+func (ti *TrafficInterceptor) SetCountryResolver(resolver CountryResolver) {
+	if resolver == nil {
 		return
 	}
-	// 使用原子操作存储配置，保证并发安全
-	interceptConf.Store(&conf)
+	ti.countryResolver.Store(resolver)
 }
 
-// getInterceptConfig 安全地获取拦截配置
-func getInterceptConfig() *InterceptConfig {
-	if v := interceptConf.Load(); v != nil {
-		return v.(*InterceptConfig)
+func (ti *TrafficInterceptor) getCountryResolver() CountryResolver {
+	if v := ti.countryResolver.Load(); v != nil {
+		return v.(CountryResolver)
 	}
 	return nil
 }
 
-// TrafficInterceptMiddleware traffic interception middleware
-func TrafficInterceptMiddleware() middleware.Middleware {
+// intn 随机源非并发安全，需要加锁
+func (ti *TrafficInterceptor) intn(n int) int {
+	ti.randMu.Lock()
+	defer ti.randMu.Unlock()
+	return ti.rand.Intn(n)
+}
+
+// Middleware traffic interception middleware
+// 需放在鉴权中间件之后，否则 UserIdFromContext 为空，uid 相关的匹配与计数均退化为 ip
+func (ti *TrafficInterceptor) Middleware() middleware.Middleware {
 	return func(handler middleware.Handler) middleware.Handler {
-		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
-			var path, hasSign, abnormal, block string
-			defer func() {
-				RecordMetricInterceptWithCtx(ctx, currentServerName, path, hasSign, abnormal, block)
-				//MetricIntercept.WithLabelValues(currentServerName, path, hasSign, abnormal, block).Inc()
-			}()
-			// parse sign
-			if tr, ok := transport.FromServerContext(ctx); ok {
-				if ht, ok := tr.(khttp.Transporter); ok {
-					path = ht.Request().URL.Path
-					requestTime := ht.Request().Header.Get("Request-Time")
-					if requestTime == "" {
-						hasSign = "0"
-					} else {
-						hasSign = "1"
-					}
-					if !verifySign(ctx, requestTime) {
-						abnormal = "1"
-						// record traffic feature
-						recordFeature(ctx, ht.Request())
-						err := getInterceptStrategy(ctx, ht.Request())
-						block = "0"
-						if err != nil {
-							block = "1"
-							return nil, err
-						}
-					} else {
-						abnormal = "0"
-					}
-				}
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			return ti.handle(ctx, req, handler)
+		}
+	}
+}
+
+func (ti *TrafficInterceptor) handle(ctx context.Context, req interface{}, handler middleware.Handler) (reply interface{}, err error) {
+	var path, hasSign, abnormal, block string
+	defer func() {
+		RecordMetricInterceptWithCtx(ctx, ti.serverName, path, hasSign, abnormal, block)
+		//MetricIntercept.WithLabelValues(ti.serverName, path, hasSign, abnormal, block).Inc()
+	}()
+	// parse sign
+	if r, ok := newInterceptRequest(ctx, req); ok {
+		path = r.path
+		requestTime := r.header("Request-Time")
+		if requestTime == "" {
+			hasSign = "0"
+		} else {
+			hasSign = "1"
+		}
+		if !ti.verifySign(ctx, r, requestTime) {
+			abnormal = "1"
+			// record traffic feature
+			recordFeature(ctx, r)
+			err := ti.getInterceptStrategy(ctx, r)
+			block = "0"
+			if err != nil {
+				block = "1"
+				return nil, err
 			}
-			reply, err = handler(ctx, req)
-			return
+		} else {
+			abnormal = "0"
+		}
+	}
+	reply, err = handler(ctx, req)
+	return
+}
+
+// ---------------- default instance ----------------
+
+// InitInterceptConfig 初始化默认流量拦截器
+// serverName: 服务名称
+// redisCli: Redis客户端
+// signCfg: 签名配置，传nil使用默认配置（禁用签名验证）
+// sources: 拦截配置来源，任一来源推送变更都会原子替换当前配置；不传时回退为每3秒轮询 Redis
+func InitInterceptConfig(serverName string, redisCli redis.UniversalClient, signCfg *SignConfig, sources ...InterceptConfigSource) {
+	once.Do(func() {
+		if redisCli == nil {
+			panic("init intercept redis client failed:invalid client")
+		}
+		opts := []TrafficInterceptorOption{
+			WithInterceptServerName(serverName),
+			WithInterceptRedis(redisCli),
+			WithInterceptSignConfig(signCfg),
+			WithInterceptSources(sources...),
+		}
+		if resolver := defaultInterceptor.Load().getCountryResolver(); resolver != nil {
+			opts = append(opts, WithInterceptCountryResolver(resolver))
+		}
+
+		// 多实例错峰加载
+		time.Sleep(time.Duration(rand.Intn(2000)) * time.Millisecond)
+		ti, err := NewTrafficInterceptor(opts...)
+		if err != nil {
+			panic(fmt.Sprintf("init intercept config failed:%v", err))
+		}
+		defaultInterceptor.Store(ti)
+	})
+}
+
+// DefaultTrafficInterceptor 返回默认拦截器，即 TrafficInterceptMiddleware 使用的实例
+func DefaultTrafficInterceptor() *TrafficInterceptor {
+	return defaultInterceptor.Load()
+}
+
+// StopInterceptConfig 停止默认拦截器的配置刷新 goroutine（用于优雅关闭）
+func StopInterceptConfig() {
+	defaultInterceptor.Load().Stop()
+}
+
+// UpdateSignConfig 动态更新默认拦截器的签名配置，整体替换密钥环
+// 密钥环校验失败时保留旧配置并返回错误
+func UpdateSignConfig(signCfg *SignConfig) error {
+	return defaultInterceptor.Load().UpdateSignConfig(signCfg)
+}
+
+// SetInterceptCountryResolver 设置默认拦截器规则中 country 字段使用的 IP 国家解析器，如 *GeoIP
+func SetInterceptCountryResolver(resolver CountryResolver) {
+	defaultInterceptor.Load().SetCountryResolver(resolver)
+}
+
+// TrafficInterceptMiddleware 使用默认拦截器的中间件，每个请求读取当前默认实例，位置要求同 TrafficInterceptor.Middleware
+func TrafficInterceptMiddleware() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			return defaultInterceptor.Load().handle(ctx, req, handler)
 		}
 	}
 }
 
 // verifySign 验证请求签名
-// 请求头格式: Request-Time: {timestamp}.{signature}
+// v1 请求头格式: Request-Time: {timestamp}.{signature}
 // 其中 timestamp 为毫秒时间戳，signature 为 HMAC-SHA256(secret, timestamp) 的前N位hex
-func verifySign(ctx context.Context, requestTime string) bool {
-	cfg := getSignConfig()
+// v2 请求头格式见 verifySignV2
+func (ti *TrafficInterceptor) verifySign(ctx context.Context, r *interceptRequest, requestTime string) bool {
+	cfg := ti.signConfig()
 
 	// 未启用签名验证，直接返回true
-	if !cfg.Enabled || cfg.Secret == "" {
+	if !cfg.enabled() {
 		return true
 	}
 
+	if strings.HasPrefix(requestTime, signV2Prefix) {
+		return ti.verifySignV2(ctx, cfg, r, requestTime)
+	}
+	if cfg.RejectV1 {
+		log.Context(ctx).Warnw(
+			"msg", "v1 request_time rejected",
+			"request_time", requestTime,
+		)
+		return false
+	}
+
 	// 解析 timestamp.signature 格式
 	parts := strings.SplitN(requestTime, ".", 2)
 	if len(parts) != 2 {
@@ -254,45 +488,13 @@ func verifySign(ctx context.Context, requestTime string) bool {
 	timestampStr := parts[0]
 	signature := parts[1]
 
-	// 验证时间戳格式
-	timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
-	if err != nil {
-		log.Context(ctx).Warnw(
-			"msg", "invalid timestamp",
-			"timestamp", timestampStr,
-		)
-		return false
-	}
-
-	// 检查时间偏差（防止重放攻击）
-	now := time.Now().UnixMilli()
-	drift := now - timestamp
-	if drift < 0 {
-		drift = -drift
-	}
-	maxDriftMs := cfg.MaxTimeDrift * 1000
-	if drift > maxDriftMs {
-		log.Context(ctx).Warnw(
-			"msg", "request_time drift too large",
-			"timestamp", timestamp,
-			"now", now,
-			"drift_ms", drift,
-			"max_drift_ms", maxDriftMs,
-		)
+	// 验证时间戳格式与时间偏差（防止重放攻击）
+	if !ti.checkSignTimestamp(ctx, cfg, timestampStr) {
 		return false
 	}
 
-	// 验证 HMAC-SHA256 签名
-	expectedSig := generateSignature(timestampStr, cfg.Secret, cfg.SignatureLength)
-	if !hmac.Equal([]byte(signature), []byte(expectedSig)) {
-		log.Context(ctx).Warnw(
-			"msg", "signature mismatch",
-			"expected_length", cfg.SignatureLength,
-		)
-		return false
-	}
-
-	return true
+	// 验证 HMAC-SHA256 签名，v1 不携带 kid，依次尝试密钥环中生效的密钥
+	return ti.verifyWithKeyring(ctx, cfg, "v1", "", timestampStr, signature)
 }
 
 // generateSignature 生成 HMAC-SHA256 签名
@@ -342,96 +544,51 @@ func sanitizeHeaders(headers http.Header) map[string]string {
 	return sanitized
 }
 
-func recordFeature(ctx context.Context, req *http.Request) {
-	path := req.URL.Path
-	referer := req.Header.Get("Referer")
-	ua := req.Header.Get("User-Agent")
+func recordFeature(ctx context.Context, r *interceptRequest) {
 	uid, _ := UserIdFromContext(ctx)
 	log.Context(ctx).Warnw(
 		"msg", "abnormal traffic feature",
-		"path", path,
-		"referer", referer,
-		"ua", ua,
+		"kind", string(r.kind),
+		"path", r.path,
+		"host", r.host,
+		"referer", r.header("Referer"),
+		"ua", r.header("User-Agent"),
 		"uid", uid,
-		"header", sanitizeHeaders(req.Header),
+		"header", sanitizeHeaders(r.headers),
 	)
 }
 
-func getInterceptStrategy(ctx context.Context, req *http.Request) error {
-	path := req.URL.Path
-	referer := req.Header.Get("Referer")
-	ua := req.Header.Get("User-Agent")
+func (ti *TrafficInterceptor) getInterceptStrategy(ctx context.Context, r *interceptRequest) error {
 	uid, _ := UserIdFromContext(ctx)
+	feature := &requestFeature{
+		path:            r.path,
+		method:          r.method,
+		host:            r.host,
+		referer:         r.header("Referer"),
+		ua:              r.header("User-Agent"),
+		uid:             uid,
+		ip:              r.ip,
+		platform:        GetPlatformFromHeader(ctx),
+		header:          r.header,
+		countryResolver: ti.getCountryResolver(),
+	}
 
 	// 使用原子操作安全地获取配置
-	conf := getInterceptConfig()
+	conf := ti.conf.Load()
 	if conf == nil || !conf.Switch {
 		return nil
 	}
 	// global rule
-	err := getStrategyByRadio(conf.Radio)
-	if err != nil {
+	if err := ti.applyAction(ctx, conf.global, feature); err != nil {
 		return err
 	}
-	for _, subRule := range conf.SubRules {
-		if !matchRule(subRule, path, referer, ua, uid) {
+	for _, subRule := range conf.compiled {
+		if !subRule.matcher.match(feature) {
 			continue
 		}
-		err := getStrategyByRadio(subRule.Radio)
-		if err != nil {
+		if err := ti.applyAction(ctx, subRule, feature); err != nil {
 			return err
 		}
 	}
 	return nil
 }
-
-func matchRule(rule SubRuleConfig, path, referer, ua, uid string) bool {
-	switch rule.Rule {
-	case "*":
-		return true
-	case "path":
-		return inSliceStr(path, strings.Split(rule.Value, ","))
-	case "referer":
-		return sliceContain(referer, strings.Split(rule.Value, ","))
-	case "ua":
-		return inSliceStr(ua, strings.Split(rule.Value, ","))
-	case "uid":
-		return inSliceStr(uid, strings.Split(rule.Value, ","))
-	default:
-		return false
-	}
-}
-
-func getStrategyByRadio(radio int) error {
-	if radio == -1 {
-		return nil
-	}
-	if radio == 0 {
-		// TODO send lark
-		return nil
-	}
-	if radio > 0 && radio <= 100 {
-		if rand.Intn(200)%100 < radio {
-			return errors.New("invalid request")
-		}
-	}
-	return nil
-}
-
-func inSliceStr(source string, target []string) bool {
-	for _, item := range target {
-		if item == source {
-			return true
-		}
-	}
-	return false
-}
-
-func sliceContain(source string, target []string) bool {
-	for _, item := range target {
-		if strings.Contains(source, item) {
-			return true
-		}
-	}
-	return false
-}
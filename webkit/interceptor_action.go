@@ -0,0 +1,354 @@
+package webkit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"github.com/seanbit/kratos/webkit/thirds"
+)
+
+// 规则命中后的处置动作
+const (
+	RuleActionBlock     = "block"
+	RuleActionShadow    = "shadow"
+	RuleActionNotify    = "notify"
+	RuleActionCaptcha   = "captcha"
+	RuleActionDelay     = "delay"
+	RuleActionRateLimit = "rate_limit"
+)
+
+// 动作执行结果，用于 server_requests_intercept_action 指标
+const (
+	actionOutcomeBlocked    = "blocked"
+	actionOutcomeShadowed   = "shadowed"
+	actionOutcomeNotified   = "notified"
+	actionOutcomeThrottled  = "throttled"
+	actionOutcomeChallenged = "challenged"
+	actionOutcomePassed     = "passed"
+	actionOutcomeDelayed    = "delayed"
+	actionOutcomeLimited    = "limited"
+	actionOutcomeAllowed    = "allowed"
+	actionOutcomeSkipped    = "skipped"
+)
+
+const (
+	// InterceptionRateLimitPrefix rate_limit 动作的 Redis 计数 key
+	InterceptionRateLimitPrefix = "traffic_interception_rl_%s:%s:%s:%d"
+
+	// InterceptBlockReason 设置了 Code 的 block 动作默认 reason，ErrorEncoder 映射为 403
+	InterceptBlockReason = "INVALID_REQUEST"
+
+	defaultActionNotifyInterval = 60
+	maxActionDelay              = 10 * time.Second
+)
+
+// RuleAction 子规则命中后的处置动作
+type RuleAction struct {
+	// Type 动作类型，取值见 RuleAction*
+	Type string `json:"type"`
+
+	// Code/Reason/Message block 与 captcha 返回的错误，block 未设置 Code 时沿用旧的 "invalid request"
+	// 自定义的 Reason 需在传给 ErrorEncoder 的 reason 映射中注册，否则 HTTP 以 200 返回
+	Code    int    `json:"code,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+
+	// NotifyInterval notify 同一规则两次告警的最小间隔（秒），默认60
+	NotifyInterval int `json:"notify_interval,omitempty"`
+
+	// DelayMs delay 注入的延迟（毫秒），最大10秒
+	DelayMs int `json:"delay_ms,omitempty"`
+
+	// Limit/Window rate_limit 每个 Key 在 Window 秒内最多放行 Limit 次
+	Limit  int `json:"limit,omitempty"`
+	Window int `json:"window,omitempty"`
+	// Key rate_limit 的计数维度：uid、ip，默认 uid，未登录时回退为 ip
+	Key string `json:"key,omitempty"`
+}
+
+// InterceptNotifier notify 动作的告警通道，模板中的 biz.IAlarmRepo 可直接使用
+type InterceptNotifier interface {
+	SendBizMessage(ctx context.Context, title, info string)
+}
+
+// ChallengeFunc captcha 动作的人机校验，返回 true 表示已通过
+type ChallengeFunc func(ctx context.Context) bool
+
+// WithInterceptNotifier notify 动作使用的告警通道
+func WithInterceptNotifier(notifier InterceptNotifier) TrafficInterceptorOption {
+	return func(ti *TrafficInterceptor) {
+		ti.notifier = notifier
+	}
+}
+
+// WithInterceptChallenge captcha 动作使用的人机校验，未设置时 captcha 动作一律要求校验
+func WithInterceptChallenge(challenge ChallengeFunc) TrafficInterceptorOption {
+	return func(ti *TrafficInterceptor) {
+		ti.challenge = challenge
+	}
+}
+
+// validate 校验并填充默认值
+func (a *RuleAction) validate() error {
+	switch a.Type {
+	case RuleActionBlock, RuleActionShadow:
+	case RuleActionNotify:
+		if a.NotifyInterval <= 0 {
+			a.NotifyInterval = defaultActionNotifyInterval
+		}
+	case RuleActionCaptcha:
+		if a.Code == 0 {
+			a.Code = 403
+		}
+		if a.Reason == "" {
+			a.Reason = ChallengeRequiredReason
+		}
+		if a.Message == "" {
+			a.Message = "captcha required"
+		}
+	case RuleActionDelay:
+		if a.DelayMs <= 0 {
+			return errors.New("delay action requires delay_ms")
+		}
+	case RuleActionRateLimit:
+		if a.Limit <= 0 || a.Window <= 0 {
+			return errors.New("rate_limit action requires limit and window")
+		}
+		switch a.Key {
+		case "":
+			a.Key = RuleFieldUID
+		case RuleFieldUID, RuleFieldIP:
+		default:
+			return errors.Errorf("unsupported rate_limit key: %q", a.Key)
+		}
+		if a.Code == 0 {
+			a.Code = 429
+		}
+		if a.Reason == "" {
+			a.Reason = RateLimitReason
+		}
+		if a.Message == "" {
+			a.Message = "too many requests"
+		}
+	default:
+		return errors.Errorf("unknown rule action: %q", a.Type)
+	}
+	return nil
+}
+
+// error 构造拦截错误，未设置 Code 的 block 保持旧行为
+func (a *RuleAction) error() error {
+	if a.Code == 0 {
+		return errors.New("invalid request")
+	}
+	reason, message := a.Reason, a.Message
+	if reason == "" {
+		reason = InterceptBlockReason
+	}
+	if message == "" {
+		message = "invalid request"
+	}
+	return kerrors.New(a.Code, reason, message)
+}
+
+// legacyRuleAction 未配置 action 的旧规则：radio 0 告警，radio 1~100 按比例拦截
+func legacyRuleAction(radio int) *RuleAction {
+	if radio == 0 {
+		return &RuleAction{Type: RuleActionNotify, NotifyInterval: defaultActionNotifyInterval}
+	}
+	return &RuleAction{Type: RuleActionBlock}
+}
+
+// sampled 按 radio 决定本次请求是否执行动作：负数不执行，1~99 为执行比例，按 StickyKey 粘性采样
+// 旧规则 0 与 100 总是执行、超过 100 不执行；配置了 action 的规则 0 与 100 以上总是执行
+func (ti *TrafficInterceptor) sampled(rule *compiledSubRule, feature *requestFeature) bool {
+	radio := rule.Radio
+	if radio < 0 {
+		return false
+	}
+	if radio > 0 && radio < 100 {
+		return ti.inPercentage(rule, feature, radio)
+	}
+	if rule.Action == nil {
+		return radio == 0 || radio == 100
+	}
+	return true
+}
+
+// applyAction 执行动作，返回非 nil 错误表示拦截请求
+func (ti *TrafficInterceptor) applyAction(ctx context.Context, rule *compiledSubRule, feature *requestFeature) (err error) {
+	action := rule.action
+	outcome := actionOutcomeSkipped
+	defer func() {
+		RecordMetricInterceptActionWithCtx(ctx, ti.serverName, feature.path, rule.name, action.Type, outcome)
+	}()
+	if !ti.sampled(rule, feature) {
+		return nil
+	}
+
+	switch action.Type {
+	case RuleActionBlock:
+		outcome = actionOutcomeBlocked
+		return action.error()
+	case RuleActionShadow:
+		outcome = actionOutcomeShadowed
+		log.Context(ctx).Warnw(
+			"msg", "shadow interception rule matched",
+			"rule", rule.name,
+			"path", feature.path,
+			"uid", feature.uid,
+			"ip", feature.ip,
+		)
+		return nil
+	case RuleActionNotify:
+		outcome = ti.notify(ctx, rule, feature)
+		return nil
+	case RuleActionCaptcha:
+		if ti.challenge != nil && ti.challenge(ctx) {
+			outcome = actionOutcomePassed
+			return nil
+		}
+		outcome = actionOutcomeChallenged
+		return action.error()
+	case RuleActionDelay:
+		outcome = actionOutcomeDelayed
+		delay := time.Duration(action.DelayMs) * time.Millisecond
+		if delay > maxActionDelay {
+			delay = maxActionDelay
+		}
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+		return nil
+	case RuleActionRateLimit:
+		if ti.allow(ctx, rule, feature) {
+			outcome = actionOutcomeAllowed
+			return nil
+		}
+		outcome = actionOutcomeLimited
+		return action.error()
+	}
+	return nil
+}
+
+// notify 同一规则在 NotifyInterval 内只告警一次，避免攻击期间刷屏
+func (ti *TrafficInterceptor) notify(ctx context.Context, rule *compiledSubRule, feature *requestFeature) string {
+	now := ti.now()
+	interval := time.Duration(rule.action.NotifyInterval) * time.Second
+	ti.notifyMu.Lock()
+	if last, ok := ti.notifyAt[rule.name]; ok && now.Sub(last) < interval {
+		ti.notifyMu.Unlock()
+		return actionOutcomeThrottled
+	}
+	if ti.notifyAt == nil {
+		ti.notifyAt = make(map[string]time.Time)
+	}
+	ti.notifyAt[rule.name] = now
+	notifier := ti.notifier
+	ti.notifyMu.Unlock()
+
+	title := fmt.Sprintf("traffic interception rule %s matched", rule.name)
+	info := fmt.Sprintf("server:%s path:%s uid:%s ip:%s ua:%s referer:%s",
+		ti.serverName, feature.path, feature.uid, feature.ip, feature.ua, feature.referer)
+	if notifier == nil {
+		log.Context(ctx).Warnw("msg", title, "info", info)
+		return actionOutcomeNotified
+	}
+	notifier.SendBizMessage(ctx, title, info)
+	return actionOutcomeNotified
+}
+
+// interceptRateLimitScript 固定窗口计数，INCR 与 PEXPIRE 原子执行，key 缺少过期时间时补上，避免计数 key 永不过期
+var interceptRateLimitScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+if redis.call('PTTL', KEYS[1]) < 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return count
+`)
+
+// allow rate_limit 固定窗口计数，有 Redis 时全局计数，否则退化为单机计数
+func (ti *TrafficInterceptor) allow(ctx context.Context, rule *compiledSubRule, feature *requestFeature) bool {
+	action := rule.action
+	subject := feature.uid
+	if action.Key == RuleFieldIP || subject == "" {
+		subject = feature.ip
+	}
+	window := time.Duration(action.Window) * time.Second
+	windowID := ti.now().UnixNano() / int64(window)
+
+	if ti.redisCli != nil {
+		key := fmt.Sprintf(InterceptionRateLimitPrefix, ti.serverName, rule.name, subject, windowID)
+		count, err := interceptRateLimitScript.Run(ctx, ti.redisCli, []string{key}, window.Milliseconds()).Int64()
+		if err != nil {
+			log.Context(ctx).Warnw(
+				"msg", "interception rate limit failed",
+				"err", err,
+			)
+			return true
+		}
+		return count <= int64(action.Limit)
+	}
+	return ti.localCounter.incr(rule.name+":"+subject, windowID) <= int64(action.Limit)
+}
+
+// windowCounter 单机固定窗口计数，窗口切换时整体清空
+type windowCounter struct {
+	mu     sync.Mutex
+	window int64
+	counts map[string]int64
+}
+
+func (c *windowCounter) incr(key string, window int64) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.counts == nil || window != c.window {
+		c.window = window
+		c.counts = make(map[string]int64)
+	}
+	c.counts[key]++
+	return c.counts[key]
+}
+
+// ---------------- notifier ----------------
+
+type larkInterceptNotifier struct {
+	alarm *thirds.Alarm
+}
+
+// NewLarkInterceptNotifier 直接通过飞书 webhook 发送告警的 InterceptNotifier
+func NewLarkInterceptNotifier(alarm *thirds.Alarm) InterceptNotifier {
+	return &larkInterceptNotifier{alarm: alarm}
+}
+
+func (n *larkInterceptNotifier) SendBizMessage(ctx context.Context, title, info string) {
+	msg := &thirds.AlarmTextMessage{
+		TraceId:   GetTraceID(ctx),
+		Operation: GetOperationFromContext(ctx),
+		Title:     title,
+		Info:      info,
+	}
+	// 告警不阻塞请求
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := n.alarm.SendTextMessage(ctx, msg); err != nil {
+			log.Errorf("send interception alarm failed:%v", err)
+		}
+	}()
+}
+
+func defaultRuleName(i int) string {
+	return "rule_" + strconv.Itoa(i)
+}
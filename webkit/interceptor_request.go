@@ -0,0 +1,119 @@
+package webkit
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/transport"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// grpcSignMethod gRPC 请求参与 v2 签名的 method，gRPC 请求在 HTTP/2 上均为 POST
+const grpcSignMethod = http.MethodPost
+
+// interceptRequest 与传输协议无关的请求信息，HTTP 与 gRPC 共用签名校验与规则匹配
+type interceptRequest struct {
+	kind     transport.Kind
+	path     string
+	method   string
+	rawQuery string
+	host     string
+	ip       string
+	header   func(key string) string
+	// headers 全部请求头，仅用于记录特征
+	headers http.Header
+	// body v2 签名使用的请求体，超过 limit 字节时返回错误
+	body func(limit int64) ([]byte, error)
+}
+
+// newInterceptRequest 从 server context 中解析请求，非 HTTP/gRPC 请求返回 false
+func newInterceptRequest(ctx context.Context, req interface{}) (*interceptRequest, bool) {
+	tr, ok := transport.FromServerContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	if ht, ok := tr.(khttp.Transporter); ok {
+		r := newHTTPInterceptRequest(ht.Request())
+		r.ip = GetRealIP(ctx)
+		return r, true
+	}
+	if tr.Kind() == transport.KindGRPC {
+		return newGRPCInterceptRequest(ctx, tr, req), true
+	}
+	return nil, false
+}
+
+func newHTTPInterceptRequest(req *http.Request) *interceptRequest {
+	return &interceptRequest{
+		kind:     transport.KindHTTP,
+		path:     req.URL.Path,
+		method:   req.Method,
+		rawQuery: req.URL.RawQuery,
+		host:     req.Host,
+		header:   req.Header.Get,
+		headers:  req.Header,
+		body: func(limit int64) ([]byte, error) {
+			return readRequestBody(req, limit)
+		},
+	}
+}
+
+// newGRPCInterceptRequest gRPC 请求以 operation 作为 path，请求头取自 incoming metadata
+// v2 签名的请求体为请求消息的确定性 protobuf 编码
+func newGRPCInterceptRequest(ctx context.Context, tr transport.Transporter, req interface{}) *interceptRequest {
+	md, _ := metadata.FromIncomingContext(ctx)
+	r := &interceptRequest{
+		kind:   transport.KindGRPC,
+		path:   tr.Operation(),
+		method: grpcSignMethod,
+		header: func(key string) string {
+			return strings.Join(md.Get(key), " ")
+		},
+		headers: http.Header(md),
+		body: func(int64) ([]byte, error) {
+			msg, ok := req.(proto.Message)
+			if !ok {
+				return nil, nil
+			}
+			return proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+		},
+	}
+	r.host = r.header(":authority")
+	r.ip = grpcRealIP(ctx, md)
+	return r
+}
+
+// grpcRealIP 优先使用网关透传的 x-real-ip / x-forwarded-for，否则取对端地址
+func grpcRealIP(ctx context.Context, md metadata.MD) string {
+	if ip := strings.Join(md.Get("x-real-ip"), ""); ip != "" && !strings.HasPrefix(ip, "10.") {
+		return ip
+	}
+	for _, values := range md.Get("x-forwarded-for") {
+		for _, ip := range strings.Split(values, ",") {
+			ip = strings.TrimSpace(ip)
+			if ip != "" && !strings.HasPrefix(ip, "10.") {
+				return ip
+			}
+		}
+	}
+	return GetClientIP(ctx)
+}
+
+// requestRealIP 客户端真实 IP，HTTP 与 gRPC 均优先使用网关透传的地址
+func requestRealIP(ctx context.Context) string {
+	tr, ok := transport.FromServerContext(ctx)
+	if !ok {
+		return ""
+	}
+	if tr.Kind() == transport.KindGRPC {
+		md, _ := metadata.FromIncomingContext(ctx)
+		return grpcRealIP(ctx, md)
+	}
+	if ip := GetRealIP(ctx); ip != "" {
+		return ip
+	}
+	return GetClientIP(ctx)
+}
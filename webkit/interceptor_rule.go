@@ -0,0 +1,417 @@
+package webkit
+
+import (
+	"net/netip"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// 规则可匹配的请求字段
+const (
+	RuleFieldPath     = "path"
+	RuleFieldMethod   = "method"
+	RuleFieldHost     = "host"
+	RuleFieldReferer  = "referer"
+	RuleFieldUA       = "ua"
+	RuleFieldUID      = "uid"
+	RuleFieldIP       = "ip"
+	RuleFieldPlatform = "platform"
+	RuleFieldCountry  = "country"
+	RuleFieldHeader   = "header"
+)
+
+// 规则匹配方式
+const (
+	RuleOpEq       = "eq"
+	RuleOpContains = "contains"
+	RuleOpPrefix   = "prefix"
+	RuleOpSuffix   = "suffix"
+	RuleOpRegex    = "regex"
+	RuleOpGlob     = "glob"
+	RuleOpCIDR     = "cidr"
+)
+
+// RuleExpr 可组合的匹配表达式
+// All/Any/Not 为组合节点，其余字段描述叶子条件，同一节点只能二选一，例如：
+//
+//	{"all": [
+//	  {"field": "ua", "op": "contains", "values": ["python-requests"]},
+//	  {"field": "path", "op": "glob", "values": ["/auth/**"]},
+//	  {"not": {"field": "country", "values": ["SG", "JP"]}}
+//	]}
+type RuleExpr struct {
+	All []*RuleExpr `json:"all,omitempty"`
+	Any []*RuleExpr `json:"any,omitempty"`
+	Not *RuleExpr   `json:"not,omitempty"`
+
+	// Field 匹配的字段，取值见 RuleField*
+	Field string `json:"field,omitempty"`
+	// Header Field 为 header 时的请求头名称
+	Header string `json:"header,omitempty"`
+	// Op 匹配方式，取值见 RuleOp*，默认 eq
+	Op string `json:"op,omitempty"`
+	// Values 任一值命中即视为匹配
+	Values []string `json:"values,omitempty"`
+}
+
+// CountryResolver 根据 IP 解析国家 ISO 编码
+type CountryResolver interface {
+	CountryCode(ip string) string
+}
+
+// requestFeature 规则匹配所需的请求特征，country 按需懒加载
+type requestFeature struct {
+	path     string
+	method   string
+	host     string
+	referer  string
+	ua       string
+	uid      string
+	ip       string
+	platform string
+	header   func(key string) string
+
+	countryResolver CountryResolver
+	country         *string
+}
+
+func (f *requestFeature) value(field, header string) string {
+	switch field {
+	case RuleFieldPath:
+		return f.path
+	case RuleFieldMethod:
+		return f.method
+	case RuleFieldHost:
+		return f.host
+	case RuleFieldReferer:
+		return f.referer
+	case RuleFieldUA:
+		return f.ua
+	case RuleFieldUID:
+		return f.uid
+	case RuleFieldIP:
+		return f.ip
+	case RuleFieldPlatform:
+		return f.platform
+	case RuleFieldCountry:
+		if f.country == nil {
+			country := ""
+			if f.countryResolver != nil && f.ip != "" {
+				country = f.countryResolver.CountryCode(f.ip)
+			}
+			f.country = &country
+		}
+		return *f.country
+	case RuleFieldHeader:
+		if f.header == nil {
+			return ""
+		}
+		return f.header(header)
+	}
+	return ""
+}
+
+// ruleMatcher 编译后的匹配器
+type ruleMatcher interface {
+	match(f *requestFeature) bool
+}
+
+type matchAll []ruleMatcher
+
+func (m matchAll) match(f *requestFeature) bool {
+	for _, sub := range m {
+		if !sub.match(f) {
+			return false
+		}
+	}
+	return true
+}
+
+type matchAny []ruleMatcher
+
+func (m matchAny) match(f *requestFeature) bool {
+	for _, sub := range m {
+		if sub.match(f) {
+			return true
+		}
+	}
+	return false
+}
+
+type matchNot struct{ sub ruleMatcher }
+
+func (m matchNot) match(f *requestFeature) bool {
+	return !m.sub.match(f)
+}
+
+type matchConst bool
+
+func (m matchConst) match(*requestFeature) bool {
+	return bool(m)
+}
+
+type matchField struct {
+	field  string
+	header string
+	test   func(v string) bool
+}
+
+func (m *matchField) match(f *requestFeature) bool {
+	return m.test(f.value(m.field, m.header))
+}
+
+// compileRuleExpr 编译表达式，非法的正则、CIDR 或字段在加载配置时即报错
+func compileRuleExpr(expr *RuleExpr) (ruleMatcher, error) {
+	if expr == nil {
+		return nil, errors.New("empty rule expression")
+	}
+	switch {
+	case len(expr.All) > 0:
+		subs, err := compileRuleExprs(expr.All)
+		if err != nil {
+			return nil, err
+		}
+		return matchAll(subs), nil
+	case len(expr.Any) > 0:
+		subs, err := compileRuleExprs(expr.Any)
+		if err != nil {
+			return nil, err
+		}
+		return matchAny(subs), nil
+	case expr.Not != nil:
+		sub, err := compileRuleExpr(expr.Not)
+		if err != nil {
+			return nil, err
+		}
+		return matchNot{sub: sub}, nil
+	}
+
+	switch expr.Field {
+	case RuleFieldPath, RuleFieldMethod, RuleFieldHost, RuleFieldReferer, RuleFieldUA, RuleFieldUID,
+		RuleFieldIP, RuleFieldPlatform, RuleFieldCountry:
+	case RuleFieldHeader:
+		if expr.Header == "" {
+			return nil, errors.New("header rule requires header name")
+		}
+	default:
+		return nil, errors.Errorf("unknown rule field: %q", expr.Field)
+	}
+	if len(expr.Values) == 0 {
+		return nil, errors.Errorf("rule field %q has no values", expr.Field)
+	}
+	test, err := compileRuleOp(expr.Op, expr.Values)
+	if err != nil {
+		return nil, errors.Wrapf(err, "rule field %q", expr.Field)
+	}
+	return &matchField{field: expr.Field, header: expr.Header, test: test}, nil
+}
+
+func compileRuleExprs(exprs []*RuleExpr) ([]ruleMatcher, error) {
+	subs := make([]ruleMatcher, 0, len(exprs))
+	for _, e := range exprs {
+		sub, err := compileRuleExpr(e)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
+
+func compileRuleOp(op string, values []string) (func(string) bool, error) {
+	switch op {
+	case "", RuleOpEq:
+		set := make(map[string]struct{}, len(values))
+		for _, v := range values {
+			set[v] = struct{}{}
+		}
+		return func(s string) bool {
+			_, ok := set[s]
+			return ok
+		}, nil
+	case RuleOpContains:
+		return anyString(values, strings.Contains), nil
+	case RuleOpPrefix:
+		return anyString(values, strings.HasPrefix), nil
+	case RuleOpSuffix:
+		return anyString(values, strings.HasSuffix), nil
+	case RuleOpRegex, RuleOpGlob:
+		patterns := make([]string, 0, len(values))
+		for _, v := range values {
+			if op == RuleOpGlob {
+				v = globToRegexp(v)
+			}
+			patterns = append(patterns, "(?:"+v+")")
+		}
+		re, err := regexp.Compile(strings.Join(patterns, "|"))
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString, nil
+	case RuleOpCIDR:
+		prefixes := make([]netip.Prefix, 0, len(values))
+		for _, v := range values {
+			prefix, err := parsePrefix(v)
+			if err != nil {
+				return nil, err
+			}
+			prefixes = append(prefixes, prefix)
+		}
+		return func(s string) bool {
+			addr, err := netip.ParseAddr(s)
+			if err != nil {
+				return false
+			}
+			addr = addr.Unmap()
+			for _, p := range prefixes {
+				if p.Contains(addr) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	}
+	return nil, errors.Errorf("unknown rule op: %q", op)
+}
+
+func anyString(values []string, fn func(s, v string) bool) func(string) bool {
+	return func(s string) bool {
+		for _, v := range values {
+			if fn(s, v) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// globToRegexp 将路径 glob 转为正则：* 匹配单段，** 匹配任意多段，? 匹配单个字符
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				b.WriteString(".*")
+				i++
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
+
+// parsePrefix 支持 CIDR 与单个 IP
+func parsePrefix(v string) (netip.Prefix, error) {
+	if strings.Contains(v, "/") {
+		prefix, err := netip.ParsePrefix(v)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		return prefix.Masked(), nil
+	}
+	addr, err := netip.ParseAddr(v)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// compileLegacyRule 兼容旧的单字段规则（*、path、referer、ua、uid，逗号分隔）
+func compileLegacyRule(rule SubRuleConfig) ruleMatcher {
+	values := strings.Split(rule.Value, ",")
+	switch rule.Rule {
+	case "*":
+		return matchConst(true)
+	case "path":
+		test, _ := compileRuleOp(RuleOpEq, values)
+		return &matchField{field: RuleFieldPath, test: test}
+	case "referer":
+		return &matchField{field: RuleFieldReferer, test: anyString(values, strings.Contains)}
+	case "ua":
+		test, _ := compileRuleOp(RuleOpEq, values)
+		return &matchField{field: RuleFieldUA, test: test}
+	case "uid":
+		test, _ := compileRuleOp(RuleOpEq, values)
+		return &matchField{field: RuleFieldUID, test: test}
+	default:
+		return matchConst(false)
+	}
+}
+
+// compiledSubRule 编译后的子规则
+type compiledSubRule struct {
+	SubRuleConfig
+	name      string
+	matcher   ruleMatcher
+	action    *RuleAction
+	stickyKey stickyKeyFunc
+}
+
+// Compile 编译所有子规则与动作，配置加载时调用一次，请求处理时不再解析规则
+func (conf *InterceptConfig) Compile() error {
+	rules := make([]*compiledSubRule, 0, len(conf.SubRules))
+	for i, subRule := range conf.SubRules {
+		rule, err := compileSubRule(i, subRule)
+		if err != nil {
+			return errors.Wrapf(err, "sub_rules[%d]", i)
+		}
+		rules = append(rules, rule)
+	}
+	// 全局 radio 视为匹配所有请求的旧规则
+	stickyKey, err := compileStickyKey(conf.StickyKey)
+	if err != nil {
+		return err
+	}
+	conf.global = &compiledSubRule{
+		SubRuleConfig: SubRuleConfig{Radio: conf.Radio},
+		name:          "global",
+		matcher:       matchConst(true),
+		action:        legacyRuleAction(conf.Radio),
+		stickyKey:     stickyKey,
+	}
+	conf.compiled = rules
+	return nil
+}
+
+func compileSubRule(i int, subRule SubRuleConfig) (*compiledSubRule, error) {
+	rule := &compiledSubRule{
+		SubRuleConfig: subRule,
+		name:          subRule.Name,
+		matcher:       compileLegacyRule(subRule),
+		action:        legacyRuleAction(subRule.Radio),
+	}
+	if rule.name == "" {
+		rule.name = defaultRuleName(i)
+	}
+	stickyKey, err := compileStickyKey(subRule.StickyKey)
+	if err != nil {
+		return nil, err
+	}
+	rule.stickyKey = stickyKey
+	if subRule.Match != nil {
+		matcher, err := compileRuleExpr(subRule.Match)
+		if err != nil {
+			return nil, err
+		}
+		rule.matcher = matcher
+	}
+	if subRule.Action != nil {
+		action := *subRule.Action
+		if err := action.validate(); err != nil {
+			return nil, err
+		}
+		rule.action = &action
+	}
+	return rule, nil
+}
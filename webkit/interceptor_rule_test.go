@@ -0,0 +1,140 @@
+package webkit
+
+import (
+	"net/http"
+	"testing"
+)
+
+type staticCountryResolver map[string]string
+
+func (r staticCountryResolver) CountryCode(ip string) string {
+	return r[ip]
+}
+
+func TestRuleExprMatch(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-App-Version", "1.2.3")
+	feature := &requestFeature{
+		path:            "/auth/login/wallet",
+		method:          http.MethodPost,
+		referer:         "https://evil.example.com/page",
+		ua:              "python-requests/2.31",
+		uid:             "1001",
+		ip:              "203.0.113.7",
+		platform:        "ios",
+		header:          header.Get,
+		countryResolver: staticCountryResolver{"203.0.113.7": "US"},
+	}
+
+	tests := []struct {
+		name string
+		expr *RuleExpr
+		want bool
+	}{
+		{"eq", &RuleExpr{Field: RuleFieldUID, Values: []string{"1000", "1001"}}, true},
+		{"eq miss", &RuleExpr{Field: RuleFieldUID, Values: []string{"1000"}}, false},
+		{"contains", &RuleExpr{Field: RuleFieldReferer, Op: RuleOpContains, Values: []string{"evil"}}, true},
+		{"prefix", &RuleExpr{Field: RuleFieldUA, Op: RuleOpPrefix, Values: []string{"python-"}}, true},
+		{"regex", &RuleExpr{Field: RuleFieldUA, Op: RuleOpRegex, Values: []string{`^curl/`, `requests/\d+`}}, true},
+		{"glob single segment", &RuleExpr{Field: RuleFieldPath, Op: RuleOpGlob, Values: []string{"/auth/*"}}, false},
+		{"glob multi segment", &RuleExpr{Field: RuleFieldPath, Op: RuleOpGlob, Values: []string{"/auth/**"}}, true},
+		{"cidr", &RuleExpr{Field: RuleFieldIP, Op: RuleOpCIDR, Values: []string{"203.0.113.0/24"}}, true},
+		{"cidr single ip", &RuleExpr{Field: RuleFieldIP, Op: RuleOpCIDR, Values: []string{"203.0.113.8"}}, false},
+		{"header", &RuleExpr{Field: RuleFieldHeader, Header: "x-app-version", Values: []string{"1.2.3"}}, true},
+		{"platform", &RuleExpr{Field: RuleFieldPlatform, Values: []string{"android", "ios"}}, true},
+		{"country", &RuleExpr{Field: RuleFieldCountry, Values: []string{"US"}}, true},
+		{"not", &RuleExpr{Not: &RuleExpr{Field: RuleFieldCountry, Values: []string{"US"}}}, false},
+		{"all", &RuleExpr{All: []*RuleExpr{
+			{Field: RuleFieldUA, Op: RuleOpContains, Values: []string{"python"}},
+			{Field: RuleFieldPath, Op: RuleOpGlob, Values: []string{"/auth/**"}},
+			{Not: &RuleExpr{Field: RuleFieldCountry, Values: []string{"SG", "JP"}}},
+		}}, true},
+		{"any", &RuleExpr{Any: []*RuleExpr{
+			{Field: RuleFieldMethod, Values: []string{http.MethodGet}},
+			{Field: RuleFieldUID, Values: []string{"1002"}},
+		}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := compileRuleExpr(tt.expr)
+			if err != nil {
+				t.Fatalf("compile: %v", err)
+			}
+			if got := m.match(feature); got != tt.want {
+				t.Errorf("match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterceptConfigCompile(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    SubRuleConfig
+		wantErr bool
+	}{
+		{"legacy", SubRuleConfig{Rule: "ua", Value: "a,b"}, false},
+		{"bad regex", SubRuleConfig{Match: &RuleExpr{Field: RuleFieldUA, Op: RuleOpRegex, Values: []string{"("}}}, true},
+		{"bad cidr", SubRuleConfig{Match: &RuleExpr{Field: RuleFieldIP, Op: RuleOpCIDR, Values: []string{"10.0.0.0/33"}}}, true},
+		{"unknown field", SubRuleConfig{Match: &RuleExpr{Field: "cookie", Values: []string{"x"}}}, true},
+		{"unknown op", SubRuleConfig{Match: &RuleExpr{Field: RuleFieldUA, Op: "like", Values: []string{"x"}}}, true},
+		{"header without name", SubRuleConfig{Match: &RuleExpr{Field: RuleFieldHeader, Values: []string{"x"}}}, true},
+		{"empty values", SubRuleConfig{Match: &RuleExpr{Field: RuleFieldUA}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &InterceptConfig{SubRules: []SubRuleConfig{tt.rule}}
+			if err := conf.Compile(); (err != nil) != tt.wantErr {
+				t.Errorf("Compile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLegacyRuleMatch(t *testing.T) {
+	feature := &requestFeature{path: "/a", referer: "https://x.com/ref", ua: "ua1", uid: "7"}
+	tests := []struct {
+		rule SubRuleConfig
+		want bool
+	}{
+		{SubRuleConfig{Rule: "*"}, true},
+		{SubRuleConfig{Rule: "path", Value: "/b,/a"}, true},
+		{SubRuleConfig{Rule: "referer", Value: "x.com"}, true},
+		{SubRuleConfig{Rule: "ua", Value: "ua"}, false},
+		{SubRuleConfig{Rule: "uid", Value: "7"}, true},
+		{SubRuleConfig{Rule: "unknown", Value: "7"}, false},
+	}
+	for _, tt := range tests {
+		if got := compileLegacyRule(tt.rule).match(feature); got != tt.want {
+			t.Errorf("rule %s=%s match() = %v, want %v", tt.rule.Rule, tt.rule.Value, got, tt.want)
+		}
+	}
+}
+
+func TestParseInterceptConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{"valid", `{"switch":true,"radio":-1,"sub_rules":[{"name":"bot","radio":100,"match":{"field":"ua","op":"contains","values":["curl"]},"action":{"type":"block"}}]}`, false},
+		{"legacy", `{"switch":true,"radio":0,"sub_rules":[{"path":"","rule":"uid","value":"1,2","radio":0}]}`, false},
+		{"malformed", `{"switch":true,`, true},
+		{"unknown field", `{"switch":true,"sub_rule":[]}`, true},
+		{"trailing data", `{"switch":true} {}`, true},
+		{"bad regex", `{"sub_rules":[{"match":{"field":"ua","op":"regex","values":["("]}}]}`, true},
+		{"unknown action", `{"sub_rules":[{"rule":"*","action":{"type":"drop"}}]}`, true},
+		{"duplicate name", `{"sub_rules":[{"rule":"*"},{"name":"rule_0","rule":"*"}]}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf, err := ParseInterceptConfig([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseInterceptConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && conf.global == nil {
+				t.Error("parsed config should be compiled")
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package webkit
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// 粘性采样的 key
+const (
+	StickyKeyUID      = "uid"
+	StickyKeyIP       = "ip"
+	StickyKeyDeviceID = "device_id"
+	// StickyKeyHeaderPrefix 以请求头取值，如 header:X-Client-Id
+	StickyKeyHeaderPrefix = "header:"
+
+	// DeviceIdHeader device_id 采样 key 读取的请求头
+	DeviceIdHeader = "Device-Id"
+
+	// stickyBuckets 采样桶数量，radio 为百分比，每 1% 对应 100 个桶
+	stickyBuckets = 10000
+)
+
+// WithInterceptHashSeed 粘性采样的哈希种子，同一服务的所有实例需保持一致，默认0
+// 更换种子会重新划分命中的客户端
+func WithInterceptHashSeed(seed uint64) TrafficInterceptorOption {
+	return func(ti *TrafficInterceptor) {
+		ti.hashSeed = seed
+	}
+}
+
+// stickyKeyFunc 从请求特征中取采样 key
+type stickyKeyFunc func(f *requestFeature) string
+
+// compileStickyKey 解析采样 key 配置，为空时使用 uid，未登录时回退为 ip
+func compileStickyKey(key string) (stickyKeyFunc, error) {
+	switch {
+	case key == "":
+		return func(f *requestFeature) string {
+			if f.uid != "" {
+				return f.uid
+			}
+			return f.ip
+		}, nil
+	case key == StickyKeyUID:
+		return func(f *requestFeature) string { return f.uid }, nil
+	case key == StickyKeyIP:
+		return func(f *requestFeature) string { return f.ip }, nil
+	case key == StickyKeyDeviceID:
+		return func(f *requestFeature) string { return f.value(RuleFieldHeader, DeviceIdHeader) }, nil
+	case strings.HasPrefix(key, StickyKeyHeaderPrefix):
+		header := strings.TrimPrefix(key, StickyKeyHeaderPrefix)
+		if header == "" {
+			return nil, errors.New("sticky key header name is empty")
+		}
+		return func(f *requestFeature) string { return f.value(RuleFieldHeader, header) }, nil
+	}
+	return nil, errors.Errorf("unknown sticky key: %q", key)
+}
+
+// inPercentage 判断请求是否落在 radio% 内
+// 以 (种子, 规则名, 采样 key) 做一致性哈希，同一客户端对同一规则的结果固定；不同规则使用规则名加盐，命中人群相互独立
+// 取不到采样 key 时退化为随机采样
+func (ti *TrafficInterceptor) inPercentage(rule *compiledSubRule, feature *requestFeature, radio int) bool {
+	key := ""
+	if rule.stickyKey != nil {
+		key = rule.stickyKey(feature)
+	}
+	if key == "" {
+		return ti.intn(100) < radio
+	}
+	return stickyBucket(ti.hashSeed, rule.name, key) < radio*stickyBuckets/100
+}
+
+// stickyBucket 计算 key 所在的桶，范围 [0, stickyBuckets)
+func stickyBucket(seed uint64, salt, key string) int {
+	h := fnv.New64a()
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], seed)
+	_, _ = h.Write(b[:])
+	_, _ = h.Write([]byte(salt))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum64() % stickyBuckets)
+}
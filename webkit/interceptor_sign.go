@@ -0,0 +1,453 @@
+package webkit
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+)
+
+const (
+	// InterceptionNoncePrefix 签名 v2 nonce 去重的 Redis key
+	InterceptionNoncePrefix = "traffic_interception_nonce_%s:%s"
+
+	// signV2Prefix v2 签名头前缀
+	// 请求头格式: Request-Time: v2.[{kid}.]{timestamp}.{nonce}.{signature}
+	signV2Prefix = "v2."
+
+	signNonceMinLength = 8
+	signNonceMaxLength = 64
+
+	// defaultSignMaxBodySize v2 签名校验默认读取的最大请求体
+	defaultSignMaxBodySize = 1 << 20
+)
+
+// verifySignV2 验证 v2 签名，签名覆盖 method、path、规范化 query、body 哈希与 nonce
+// kid 可选，携带时只使用对应密钥校验；nonce 通过 SETNX 记录在 Redis 中，同一 nonce 在时间窗口内重复出现视为重放
+func (ti *TrafficInterceptor) verifySignV2(ctx context.Context, cfg *SignConfig, r *interceptRequest, requestTime string) bool {
+	parts := strings.Split(strings.TrimPrefix(requestTime, signV2Prefix), ".")
+	var kid string
+	switch len(parts) {
+	case 3:
+	case 4:
+		kid, parts = parts[0], parts[1:]
+	default:
+		log.Context(ctx).Warnw(
+			"msg", "invalid request_time format, expected: v2.[kid.]timestamp.nonce.signature",
+			"request_time", requestTime,
+		)
+		return false
+	}
+	timestampStr, nonce, signature := parts[0], parts[1], parts[2]
+
+	if !validSignNonce(nonce) {
+		log.Context(ctx).Warnw(
+			"msg", "invalid nonce",
+			"nonce", nonce,
+		)
+		return false
+	}
+	if !ti.checkSignTimestamp(ctx, cfg, timestampStr) {
+		return false
+	}
+
+	body, err := r.body(cfg.MaxBodySize)
+	if err != nil {
+		log.Context(ctx).Warnw(
+			"msg", "read request body failed",
+			"err", err,
+		)
+		return false
+	}
+	payload := signV2Payload(timestampStr, nonce, r.method, r.path, r.rawQuery, body)
+	if !ti.verifyWithKeyring(ctx, cfg, "v2", kid, payload, signature) {
+		return false
+	}
+
+	return ti.checkSignNonce(ctx, cfg, nonce)
+}
+
+// checkSignTimestamp 校验时间戳格式与时间偏差
+func (ti *TrafficInterceptor) checkSignTimestamp(ctx context.Context, cfg *SignConfig, timestampStr string) bool {
+	timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
+	if err != nil {
+		log.Context(ctx).Warnw(
+			"msg", "invalid timestamp",
+			"timestamp", timestampStr,
+		)
+		return false
+	}
+
+	now := ti.now().UnixMilli()
+	drift := now - timestamp
+	if drift < 0 {
+		drift = -drift
+	}
+	maxDriftMs := cfg.MaxTimeDrift * 1000
+	if drift > maxDriftMs {
+		log.Context(ctx).Warnw(
+			"msg", "request_time drift too large",
+			"timestamp", timestamp,
+			"now", now,
+			"drift_ms", drift,
+			"max_drift_ms", maxDriftMs,
+		)
+		return false
+	}
+	return true
+}
+
+// checkSignNonce 使用 SETNX 记录 nonce，已存在则判定为重放
+// 时间戳允许前后各 MaxTimeDrift 的偏差，nonce 需保留两倍窗口；Redis 不可用时放行，避免误伤正常流量
+func (ti *TrafficInterceptor) checkSignNonce(ctx context.Context, cfg *SignConfig, nonce string) bool {
+	if ti.redisCli == nil {
+		return true
+	}
+	key := fmt.Sprintf(InterceptionNoncePrefix, ti.serverName, nonce)
+	ttl := time.Duration(cfg.MaxTimeDrift) * time.Second * 2
+	ok, err := ti.redisCli.SetNX(ctx, key, 1, ttl).Result()
+	if err != nil {
+		log.Context(ctx).Warnw(
+			"msg", "record nonce failed",
+			"err", err,
+		)
+		return true
+	}
+	if !ok {
+		log.Context(ctx).Warnw(
+			"msg", "nonce replayed",
+			"nonce", nonce,
+		)
+		return false
+	}
+	return true
+}
+
+func validSignNonce(nonce string) bool {
+	if len(nonce) < signNonceMinLength || len(nonce) > signNonceMaxLength {
+		return false
+	}
+	return validSignToken(nonce)
+}
+
+// validSignToken 仅允许字母、数字、- 与 _
+func validSignToken(token string) bool {
+	for _, c := range token {
+		switch {
+		case c >= '0' && c <= '9', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '-', c == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// readRequestBody 读取请求体并重置，保证后续 handler 仍可读取；limit 大于0时最多读取 limit 字节，超过返回错误
+func readRequestBody(req *http.Request, limit int64) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if limit <= 0 {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		return body, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(req.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		// 已读取的部分放回，未读取的部分仍由原 Body 提供
+		req.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), req.Body), req.Body}
+		return nil, errors.Errorf("request body exceeds %d bytes", limit)
+	}
+	_ = req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// signV2Payload 构造 v2 待签名串，各字段以换行分隔
+func signV2Payload(timestamp, nonce, method, path, rawQuery string, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+	return strings.Join([]string{
+		"v2",
+		timestamp,
+		nonce,
+		strings.ToUpper(method),
+		path,
+		canonicalQuery(rawQuery),
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+}
+
+// canonicalQuery 规范化 query：按 key 排序，同名参数按值排序，统一编码
+func canonicalQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return rawQuery
+	}
+	for _, v := range values {
+		sort.Strings(v)
+	}
+	return values.Encode()
+}
+
+// GenerateRequestTimeV2 生成 v2 签名请求头（供客户端使用）
+// rawQuery 为未解码的 query 字符串（不含 ?），body 为原始请求体
+// 返回格式: v2.{timestamp}.{nonce}.{signature}
+func GenerateRequestTimeV2(secret string, signatureLength int, method, path, rawQuery string, body []byte) string {
+	return GenerateRequestTimeV2WithKey(SignKey{Secret: secret}, signatureLength, method, path, rawQuery, body)
+}
+
+// GenerateRequestTimeV2WithKey 使用密钥环中的密钥生成 v2 签名请求头，key.Kid 为空时不携带 kid
+// 返回格式: v2.{kid}.{timestamp}.{nonce}.{signature}
+func GenerateRequestTimeV2WithKey(key SignKey, signatureLength int, method, path, rawQuery string, body []byte) string {
+	timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	nonce := generateSignNonce()
+	payload := signV2Payload(timestamp, nonce, method, path, rawQuery, body)
+	signature := generateSignature(payload, key.Secret, signatureLength)
+	if key.Kid == "" {
+		return signV2Prefix + timestamp + "." + nonce + "." + signature
+	}
+	return signV2Prefix + key.Kid + "." + timestamp + "." + nonce + "." + signature
+}
+
+// SignRequest 为 http.Request 设置 v2 签名请求头（供客户端使用），会读取并重置请求体
+func SignRequest(req *http.Request, secret string, signatureLength int) error {
+	return SignRequestWithKey(req, SignKey{Secret: secret}, signatureLength)
+}
+
+// SignRequestWithKey 使用指定密钥为 http.Request 设置 v2 签名请求头
+func SignRequestWithKey(req *http.Request, key SignKey, signatureLength int) error {
+	body, err := readRequestBody(req, 0)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Request-Time", GenerateRequestTimeV2WithKey(key, signatureLength, req.Method, req.URL.Path, req.URL.RawQuery, body))
+	return nil
+}
+
+// SignRequestWithConfig 使用 SignConfig 当前的签名密钥为 http.Request 设置 v2 签名请求头，用于服务间调用
+func SignRequestWithConfig(req *http.Request, cfg *SignConfig) error {
+	if cfg.ring == nil {
+		if err := cfg.normalize(); err != nil {
+			return err
+		}
+	}
+	key, ok := cfg.SigningKey()
+	if !ok {
+		return errors.New("no active signing key")
+	}
+	return SignRequestWithKey(req, key, cfg.SignatureLength)
+}
+
+func generateSignNonce() string {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand 不会失败，兜底使用纳秒时间
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return hex.EncodeToString(b)
+}
+
+// ---------------- keyring ----------------
+
+// DefaultSignKid SignConfig.Secret 对应的密钥 ID
+const DefaultSignKid = "default"
+
+// 签名密钥校验结果，用于 server_requests_sign_key 指标
+const (
+	signKeyResultOK          = "ok"
+	signKeyResultMismatch    = "mismatch"
+	signKeyResultUnknown     = "unknown_kid"
+	signKeyResultNotYetValid = "not_yet_valid"
+	signKeyResultExpired     = "expired"
+
+	// signKidUnknown 不在密钥环中的 kid 在指标中的取值
+	signKidUnknown = "unknown"
+)
+
+// SignKey 签名密钥
+type SignKey struct {
+	// Kid 密钥 ID，仅允许字母、数字、- 与 _
+	Kid string
+	// Secret HMAC 密钥
+	Secret string
+	// NotBefore 生效时间，零值表示立即生效
+	NotBefore time.Time
+	// ExpiresAt 过期时间，零值表示永不过期
+	ExpiresAt time.Time
+}
+
+// state 返回密钥在 now 时刻的状态
+func (k *SignKey) state(now time.Time) string {
+	if !k.NotBefore.IsZero() && now.Before(k.NotBefore) {
+		return signKeyResultNotYetValid
+	}
+	if !k.ExpiresAt.IsZero() && !now.Before(k.ExpiresAt) {
+		return signKeyResultExpired
+	}
+	return signKeyResultOK
+}
+
+// normalize 填充默认值并校验密钥环，Secret 非空时作为 kid 为 default 的密钥加入密钥环
+func (c *SignConfig) normalize() error {
+	if c.SignatureLength <= 0 {
+		c.SignatureLength = 8
+	}
+	if c.MaxTimeDrift <= 0 {
+		c.MaxTimeDrift = 300
+	}
+	if c.MaxBodySize <= 0 {
+		c.MaxBodySize = defaultSignMaxBodySize
+	}
+	keys := make([]SignKey, 0, len(c.Keys)+1)
+	if c.Secret != "" {
+		keys = append(keys, SignKey{Kid: DefaultSignKid, Secret: c.Secret})
+	}
+	keys = append(keys, c.Keys...)
+	ring := make(map[string]*SignKey, len(keys))
+	for i := range keys {
+		key := &keys[i]
+		if !validSignKid(key.Kid) {
+			return errors.Errorf("invalid sign key id: %q", key.Kid)
+		}
+		if key.Secret == "" {
+			return errors.Errorf("sign key %s has empty secret", key.Kid)
+		}
+		if _, ok := ring[key.Kid]; ok {
+			return errors.Errorf("duplicate sign key id: %s", key.Kid)
+		}
+		ring[key.Kid] = key
+	}
+	if c.SigningKid != "" {
+		if _, ok := ring[c.SigningKid]; !ok {
+			return errors.Errorf("signing key %s not found in keyring", c.SigningKid)
+		}
+	}
+	c.keys = keys
+	c.ring = ring
+	return nil
+}
+
+// enabled 是否需要验证签名
+func (c *SignConfig) enabled() bool {
+	return c.Enabled && len(c.keys) > 0
+}
+
+// SigningKey 返回当前用于签名的密钥：优先 SigningKid，否则取第一个生效中的密钥
+func (c *SignConfig) SigningKey() (SignKey, bool) {
+	now := time.Now()
+	if c.SigningKid != "" {
+		if key, ok := c.ring[c.SigningKid]; ok && key.state(now) == signKeyResultOK {
+			return *key, true
+		}
+		return SignKey{}, false
+	}
+	for i := range c.keys {
+		if c.keys[i].state(now) == signKeyResultOK {
+			return c.keys[i], true
+		}
+	}
+	return SignKey{}, false
+}
+
+// verifyWithKeyring 使用密钥环校验签名并记录密钥使用指标
+// kid 为空时依次尝试所有生效中的密钥，兼容未携带 kid 的旧客户端
+func (ti *TrafficInterceptor) verifyWithKeyring(ctx context.Context, cfg *SignConfig, version, kid, payload, signature string) bool {
+	now := ti.now()
+	match := func(key *SignKey) bool {
+		expectedSig := generateSignature(payload, key.Secret, cfg.SignatureLength)
+		return hmac.Equal([]byte(signature), []byte(expectedSig))
+	}
+
+	if kid != "" {
+		// kid 来自请求头，不在密钥环中时指标统一记为 unknown，避免标签基数随请求增长
+		if !validSignKid(kid) {
+			RecordMetricSignKeyWithCtx(ctx, ti.serverName, signKidUnknown, version, signKeyResultUnknown)
+			log.Context(ctx).Warnw(
+				"msg", "invalid sign key id",
+				"kid_length", len(kid),
+			)
+			return false
+		}
+		key, ok := cfg.ring[kid]
+		if !ok {
+			RecordMetricSignKeyWithCtx(ctx, ti.serverName, signKidUnknown, version, signKeyResultUnknown)
+			log.Context(ctx).Warnw(
+				"msg", "unknown sign key",
+				"kid", kid,
+			)
+			return false
+		}
+		if state := key.state(now); state != signKeyResultOK {
+			RecordMetricSignKeyWithCtx(ctx, ti.serverName, kid, version, state)
+			log.Context(ctx).Warnw(
+				"msg", "sign key not active",
+				"kid", kid,
+				"state", state,
+			)
+			return false
+		}
+		if !match(key) {
+			RecordMetricSignKeyWithCtx(ctx, ti.serverName, kid, version, signKeyResultMismatch)
+			log.Context(ctx).Warnw(
+				"msg", "signature mismatch",
+				"version", version,
+				"kid", kid,
+				"expected_length", cfg.SignatureLength,
+			)
+			return false
+		}
+		RecordMetricSignKeyWithCtx(ctx, ti.serverName, kid, version, signKeyResultOK)
+		return true
+	}
+
+	for i := range cfg.keys {
+		key := &cfg.keys[i]
+		if key.state(now) != signKeyResultOK {
+			continue
+		}
+		if match(key) {
+			RecordMetricSignKeyWithCtx(ctx, ti.serverName, key.Kid, version, signKeyResultOK)
+			return true
+		}
+	}
+	RecordMetricSignKeyWithCtx(ctx, ti.serverName, "", version, signKeyResultMismatch)
+	log.Context(ctx).Warnw(
+		"msg", "signature mismatch",
+		"version", version,
+		"expected_length", cfg.SignatureLength,
+	)
+	return false
+}
+
+func validSignKid(kid string) bool {
+	if kid == "" || len(kid) > 32 {
+		return false
+	}
+	return validSignToken(kid)
+}
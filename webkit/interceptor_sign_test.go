@@ -0,0 +1,209 @@
+package webkit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestSignRequestV2(t *testing.T) {
+	cfg := &SignConfig{Secret: "secret", SignatureLength: 16, MaxTimeDrift: 300, Enabled: true}
+	ti, err := NewTrafficInterceptor(WithInterceptSignConfig(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	newRequest := func() *http.Request {
+		return httptest.NewRequest(http.MethodPost, "/auth/login?b=2&a=1&a=0", strings.NewReader(`{"wallet":"0x1"}`))
+	}
+
+	req := newRequest()
+	if err := SignRequest(req, cfg.Secret, cfg.SignatureLength); err != nil {
+		t.Fatal(err)
+	}
+	header := req.Header.Get("Request-Time")
+	if !strings.HasPrefix(header, signV2Prefix) {
+		t.Fatalf("unexpected header: %s", header)
+	}
+	if !ti.verifySignV2(context.Background(), cfg, newHTTPInterceptRequest(req), header) {
+		t.Fatal("signature should be valid")
+	}
+	// 校验后请求体仍可读取
+	if body, _ := io.ReadAll(req.Body); string(body) != `{"wallet":"0x1"}` {
+		t.Fatalf("body not restored: %s", body)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(r *http.Request) *http.Request
+	}{
+		{"path", func(r *http.Request) *http.Request {
+			r.URL.Path = "/auth/logout"
+			return r
+		}},
+		{"method", func(r *http.Request) *http.Request {
+			r.Method = http.MethodPut
+			return r
+		}},
+		{"query", func(r *http.Request) *http.Request {
+			r.URL.RawQuery = "a=1&b=2"
+			return r
+		}},
+		{"body", func(r *http.Request) *http.Request {
+			r.Body = io.NopCloser(strings.NewReader(`{"wallet":"0x2"}`))
+			return r
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if ti.verifySignV2(context.Background(), cfg, newHTTPInterceptRequest(tt.mutate(newRequest())), header) {
+				t.Error("tampered request should be rejected")
+			}
+		})
+	}
+}
+
+// 超过 MaxBodySize 的请求体不参与签名校验，且不影响后续读取
+func TestSignRequestV2MaxBodySize(t *testing.T) {
+	cfg := &SignConfig{Secret: "secret", SignatureLength: 16, MaxTimeDrift: 300, Enabled: true, MaxBodySize: 8}
+	ti, err := NewTrafficInterceptor(WithInterceptSignConfig(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := `{"wallet":"0x1"}`
+	req := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(body))
+	if err := SignRequest(req, cfg.Secret, cfg.SignatureLength); err != nil {
+		t.Fatal(err)
+	}
+	if ti.verifySignV2(context.Background(), cfg, newHTTPInterceptRequest(req), req.Header.Get("Request-Time")) {
+		t.Error("oversized body should be rejected")
+	}
+	if got, _ := io.ReadAll(req.Body); string(got) != body {
+		t.Errorf("body not restored: %s", got)
+	}
+}
+
+func TestCanonicalQuery(t *testing.T) {
+	if got, want := canonicalQuery("b=2&a=1&a=0"), canonicalQuery("a=0&a=1&b=2"); got != want {
+		t.Errorf("canonicalQuery() = %q, want %q", got, want)
+	}
+}
+
+func TestSignKeyring(t *testing.T) {
+	now := time.Now()
+	cfg := &SignConfig{
+		SignatureLength: 16,
+		Enabled:         true,
+		Keys: []SignKey{
+			{Kid: "k1", Secret: "old", ExpiresAt: now.Add(time.Hour)},
+			{Kid: "k2", Secret: "new"},
+			{Kid: "k3", Secret: "future", NotBefore: now.Add(time.Hour)},
+			{Kid: "k0", Secret: "retired", ExpiresAt: now.Add(-time.Minute)},
+		},
+		SigningKid: "k2",
+	}
+	ti, err := NewTrafficInterceptor(WithInterceptSignConfig(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key, ok := cfg.SigningKey(); !ok || key.Kid != "k2" {
+		t.Fatalf("SigningKey() = %v, %v", key.Kid, ok)
+	}
+
+	tests := []struct {
+		name string
+		key  SignKey
+		want bool
+	}{
+		{"kid old", SignKey{Kid: "k1", Secret: "old"}, true},
+		{"kid new", SignKey{Kid: "k2", Secret: "new"}, true},
+		{"without kid", SignKey{Secret: "old"}, true},
+		{"not yet valid", SignKey{Kid: "k3", Secret: "future"}, false},
+		{"expired", SignKey{Kid: "k0", Secret: "retired"}, false},
+		{"expired without kid", SignKey{Secret: "retired"}, false},
+		{"unknown kid", SignKey{Kid: "k9", Secret: "new"}, false},
+		{"wrong secret", SignKey{Kid: "k2", Secret: "old"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/probe", nil)
+			if err := SignRequestWithKey(req, tt.key, cfg.SignatureLength); err != nil {
+				t.Fatal(err)
+			}
+			if got := ti.verifySignV2(context.Background(), cfg, newHTTPInterceptRequest(req), req.Header.Get("Request-Time")); got != tt.want {
+				t.Errorf("verifySignV2() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// 请求头中不在密钥环的 kid 不应作为指标标签
+func TestSignKeyUnknownKidMetric(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	if err := InitMetrics("webkit_test", WithMetricsExporters(), WithMetricsReader(reader)); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := ShutdownMetrics(context.Background()); err != nil {
+			t.Error(err)
+		}
+	}()
+	cfg := &SignConfig{SignatureLength: 16, Enabled: true, Keys: []SignKey{{Kid: "k1", Secret: "s"}}}
+	ti, err := NewTrafficInterceptor(WithInterceptSignConfig(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, kid := range []string{"k9", "random-" + strings.Repeat("x", 40), "a/b"} {
+		if ti.verifyWithKeyring(context.Background(), cfg, "v2", kid, "payload", "signature") {
+			t.Errorf("kid %q should not verify", kid)
+		}
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "server_requests_sign_key" {
+				continue
+			}
+			sum := m.Data.(metricdata.Sum[int64])
+			if len(sum.DataPoints) != 1 {
+				t.Fatalf("data points = %d, want 1", len(sum.DataPoints))
+			}
+			dp := sum.DataPoints[0]
+			if kid, _ := dp.Attributes.Value("kid"); kid.AsString() != signKidUnknown || dp.Value != 3 {
+				t.Errorf("kid = %q, value = %d", kid.AsString(), dp.Value)
+			}
+			return
+		}
+	}
+	t.Error("server_requests_sign_key not collected")
+}
+
+func TestSignConfigNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  SignConfig
+	}{
+		{"duplicate kid", SignConfig{Keys: []SignKey{{Kid: "a", Secret: "1"}, {Kid: "a", Secret: "2"}}}},
+		{"default collides with secret", SignConfig{Secret: "s", Keys: []SignKey{{Kid: DefaultSignKid, Secret: "2"}}}},
+		{"invalid kid", SignConfig{Keys: []SignKey{{Kid: "a.b", Secret: "1"}}}},
+		{"empty secret", SignConfig{Keys: []SignKey{{Kid: "a"}}}},
+		{"missing signing kid", SignConfig{Keys: []SignKey{{Kid: "a", Secret: "1"}}, SigningKid: "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.normalize(); err == nil {
+				t.Error("normalize() should fail")
+			}
+		})
+	}
+}
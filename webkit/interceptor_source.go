@@ -0,0 +1,335 @@
+package webkit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/config/file"
+	"github.com/go-kratos/kratos/v2/encoding"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// InterceptionChannelPrefix 拦截配置变更通知的 Redis 频道
+	InterceptionChannelPrefix = "traffic_interception_channel_%s"
+
+	// interceptPollInterval Redis 轮询拉取配置的间隔
+	interceptPollInterval = 3 * time.Second
+	// interceptResyncInterval 推送类来源的兜底全量同步间隔，避免 pub/sub 丢消息后配置长期不一致
+	interceptResyncInterval = time.Minute
+)
+
+// InterceptConfigSource 拦截配置来源
+// Load 同步拉取一次当前配置；Watch 阻塞运行直到 ctx 取消，配置变化时调用 onChange
+type InterceptConfigSource interface {
+	Load(ctx context.Context) (*InterceptConfig, error)
+	Watch(ctx context.Context, onChange func(*InterceptConfig)) error
+}
+
+// ---------------- redis polling ----------------
+
+type redisPollInterceptSource struct {
+	cli      redis.UniversalClient
+	key      string
+	interval time.Duration
+}
+
+// NewRedisPollInterceptSource 基于 Redis 定时轮询的配置来源
+func NewRedisPollInterceptSource(cli redis.UniversalClient, key string, interval time.Duration) InterceptConfigSource {
+	if interval <= 0 {
+		interval = interceptPollInterval
+	}
+	return &redisPollInterceptSource{cli: cli, key: key, interval: interval}
+}
+
+func (s *redisPollInterceptSource) Load(ctx context.Context) (*InterceptConfig, error) {
+	return loadInterceptConfigFromRedis(ctx, s.cli, s.key)
+}
+
+func (s *redisPollInterceptSource) Watch(ctx context.Context, onChange func(*InterceptConfig)) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			conf, err := s.Load(ctx)
+			if err != nil {
+				log.Warnf("reload interception config failed:%v", err)
+				continue
+			}
+			onChange(conf)
+		}
+	}
+}
+
+// ---------------- redis pub/sub ----------------
+
+type redisPubSubInterceptSource struct {
+	cli     redis.UniversalClient
+	key     string
+	channel string
+}
+
+// NewRedisChannelInterceptSource 订阅 Redis 频道的配置来源
+// 发布方先写入 key 再向 channel 发布任意消息（见 PublishInterceptConfig），收到通知后重新读取 key
+func NewRedisChannelInterceptSource(cli redis.UniversalClient, key, channel string) InterceptConfigSource {
+	return &redisPubSubInterceptSource{cli: cli, key: key, channel: channel}
+}
+
+// NewRedisKeyspaceInterceptSource 基于 keyspace notification 的配置来源
+// 需要 Redis 开启 notify-keyspace-events（至少包含 K$），db 为 key 所在的库；集群模式下 db 传 0
+func NewRedisKeyspaceInterceptSource(cli redis.UniversalClient, db int, key string) InterceptConfigSource {
+	return &redisPubSubInterceptSource{
+		cli:     cli,
+		key:     key,
+		channel: fmt.Sprintf("__keyspace@%d__:%s", db, key),
+	}
+}
+
+func (s *redisPubSubInterceptSource) Load(ctx context.Context) (*InterceptConfig, error) {
+	return loadInterceptConfigFromRedis(ctx, s.cli, s.key)
+}
+
+func (s *redisPubSubInterceptSource) Watch(ctx context.Context, onChange func(*InterceptConfig)) error {
+	pubsub := s.cli.Subscribe(ctx, s.channel)
+	defer pubsub.Close()
+	// 确认订阅成功，失败时交由调用方重试
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return errors.Wrapf(err, "subscribe %s", s.channel)
+	}
+
+	reload := func() {
+		conf, err := s.Load(ctx)
+		if err != nil {
+			log.Warnf("reload interception config failed:%v", err)
+			return
+		}
+		onChange(conf)
+	}
+
+	resync := time.NewTicker(interceptResyncInterval)
+	defer resync.Stop()
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-ch:
+			if !ok {
+				return errors.Errorf("subscription %s closed", s.channel)
+			}
+			reload()
+		case <-resync.C:
+			reload()
+		}
+	}
+}
+
+// PublishInterceptConfig 校验并写入拦截配置，再通知订阅方；无法编译的配置不会写入
+func PublishInterceptConfig(ctx context.Context, cli redis.UniversalClient, serverName string, conf *InterceptConfig) error {
+	if err := conf.Compile(); err != nil {
+		return errors.Wrap(err, "invalid interception config")
+	}
+	data, err := json.Marshal(conf)
+	if err != nil {
+		return err
+	}
+	if err := cli.Set(ctx, fmt.Sprintf(InterceptionKeyPrefix, serverName), data, 0).Err(); err != nil {
+		return err
+	}
+	return cli.Publish(ctx, fmt.Sprintf(InterceptionChannelPrefix, serverName), "reload").Err()
+}
+
+// ParseInterceptConfig 严格解析 JSON 拦截配置，用于发布前的校验
+// 相比运行时加载额外拒绝未知字段、多余内容与重名规则，并编译全部规则，返回的配置可直接发布
+func ParseInterceptConfig(data []byte) (*InterceptConfig, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var conf InterceptConfig
+	if err := dec.Decode(&conf); err != nil {
+		return nil, errors.Wrap(err, "decode interception config")
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after interception config")
+	}
+	names := make(map[string]int, len(conf.SubRules))
+	for i, subRule := range conf.SubRules {
+		name := subRule.Name
+		if name == "" {
+			name = defaultRuleName(i)
+		}
+		if j, ok := names[name]; ok {
+			return nil, errors.Errorf("sub_rules[%d] and sub_rules[%d] have the same name %q", j, i, name)
+		}
+		names[name] = i
+	}
+	if err := conf.Compile(); err != nil {
+		return nil, err
+	}
+	return &conf, nil
+}
+
+func loadInterceptConfigFromRedis(ctx context.Context, cli redis.UniversalClient, key string) (*InterceptConfig, error) {
+	data, err := cli.Get(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	var conf InterceptConfig
+	if err := json.Unmarshal([]byte(data), &conf); err != nil {
+		return nil, errors.Wrapf(err, "unmarshal interception config, data:%v", data)
+	}
+	return &conf, nil
+}
+
+// ---------------- local file ----------------
+
+type fileInterceptSource struct {
+	src config.Source
+}
+
+// NewFileInterceptSource 监听本地 YAML/JSON 文件的配置来源，文件内容即 InterceptConfig
+func NewFileInterceptSource(path string) InterceptConfigSource {
+	return &fileInterceptSource{src: file.NewSource(path)}
+}
+
+func (s *fileInterceptSource) Load(context.Context) (*InterceptConfig, error) {
+	kvs, err := s.src.Load()
+	if err != nil {
+		return nil, err
+	}
+	return decodeInterceptKeyValues(kvs)
+}
+
+func (s *fileInterceptSource) Watch(ctx context.Context, onChange func(*InterceptConfig)) error {
+	w, err := s.src.Watch()
+	if err != nil {
+		return err
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		// Next 会一直阻塞，需要通过 Stop 唤醒
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		_ = w.Stop()
+	}()
+	for {
+		kvs, err := w.Next()
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		conf, err := decodeInterceptKeyValues(kvs)
+		if err != nil {
+			log.Warnf("decode interception config file failed:%v", err)
+			continue
+		}
+		onChange(conf)
+	}
+}
+
+func decodeInterceptKeyValues(kvs []*config.KeyValue) (*InterceptConfig, error) {
+	if len(kvs) == 0 {
+		return nil, errors.New("empty interception config")
+	}
+	kv := kvs[0]
+	codec := encoding.GetCodec(kv.Format)
+	if codec == nil {
+		return nil, errors.Errorf("unsupported interception config format: %s", kv.Format)
+	}
+	// 先解码为通用结构再转 JSON，保证 YAML 与 JSON 共用 json tag
+	var raw map[string]any
+	if err := codec.Unmarshal(kv.Value, &raw); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var conf InterceptConfig
+	if err := json.Unmarshal(data, &conf); err != nil {
+		return nil, err
+	}
+	return &conf, nil
+}
+
+// ---------------- kratos config ----------------
+
+type kratosConfigInterceptSource struct {
+	conf config.Config
+	key  string
+
+	mu     sync.Mutex
+	loaded bool
+}
+
+// NewKratosConfigInterceptSource 基于 kratos config.Source 的配置来源，读取 key 下的 InterceptConfig
+// 可直接复用 aws appconfig、etcd、nacos 等 kratos 配置中心
+func NewKratosConfigInterceptSource(src config.Source, key string) InterceptConfigSource {
+	return &kratosConfigInterceptSource{
+		conf: config.New(config.WithSource(src)),
+		key:  key,
+	}
+}
+
+// load config.Load 会为每个 source 启动 watcher，成功后不再重复调用，失败时下次可重试
+func (s *kratosConfigInterceptSource) load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.loaded {
+		return nil
+	}
+	if err := s.conf.Load(); err != nil {
+		return err
+	}
+	s.loaded = true
+	return nil
+}
+
+func (s *kratosConfigInterceptSource) Load(context.Context) (*InterceptConfig, error) {
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s.scan(s.conf.Value(s.key))
+}
+
+func (s *kratosConfigInterceptSource) Watch(ctx context.Context, onChange func(*InterceptConfig)) error {
+	if err := s.load(); err != nil {
+		return err
+	}
+	err := s.conf.Watch(s.key, func(_ string, v config.Value) {
+		conf, err := s.scan(v)
+		if err != nil {
+			log.Warnf("scan interception config failed:%v", err)
+			return
+		}
+		onChange(conf)
+	})
+	if err != nil {
+		return err
+	}
+	<-ctx.Done()
+	return s.conf.Close()
+}
+
+func (s *kratosConfigInterceptSource) scan(v config.Value) (*InterceptConfig, error) {
+	var conf InterceptConfig
+	if err := v.Scan(&conf); err != nil {
+		return nil, err
+	}
+	return &conf, nil
+}
@@ -0,0 +1,171 @@
+package webkit
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/config"
+)
+
+// memoryConfigSource 内存中的 kratos config.Source，update 推送新内容
+type memoryConfigSource struct {
+	kv      *config.KeyValue
+	changes chan *config.KeyValue
+}
+
+func newMemoryConfigSource(format string, value []byte) *memoryConfigSource {
+	return &memoryConfigSource{
+		kv:      &config.KeyValue{Key: "memory", Format: format, Value: value},
+		changes: make(chan *config.KeyValue, 1),
+	}
+}
+
+func (s *memoryConfigSource) update(value []byte) {
+	s.changes <- &config.KeyValue{Key: s.kv.Key, Format: s.kv.Format, Value: value}
+}
+
+func (s *memoryConfigSource) Load() ([]*config.KeyValue, error) {
+	return []*config.KeyValue{s.kv}, nil
+}
+
+func (s *memoryConfigSource) Watch() (config.Watcher, error) {
+	return &memoryConfigWatcher{src: s, stop: make(chan struct{})}, nil
+}
+
+type memoryConfigWatcher struct {
+	src  *memoryConfigSource
+	stop chan struct{}
+}
+
+func (w *memoryConfigWatcher) Next() ([]*config.KeyValue, error) {
+	select {
+	case kv := <-w.src.changes:
+		return []*config.KeyValue{kv}, nil
+	case <-w.stop:
+		return nil, context.Canceled
+	}
+}
+
+func (w *memoryConfigWatcher) Stop() error {
+	close(w.stop)
+	return nil
+}
+
+// watchInterceptSource 后台运行 Watch，返回收到的配置与等待 Watch 退出的函数
+func watchInterceptSource(t *testing.T, src InterceptConfigSource) (<-chan *InterceptConfig, func()) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	changes := make(chan *InterceptConfig, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- src.Watch(ctx, func(conf *InterceptConfig) {
+			changes <- conf
+		})
+	}()
+	return changes, func() {
+		cancel()
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("Watch() = %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Error("Watch did not return after cancel")
+		}
+	}
+}
+
+func waitInterceptConfig(t *testing.T, changes <-chan *InterceptConfig) *InterceptConfig {
+	t.Helper()
+	select {
+	case conf := <-changes:
+		return conf
+	case <-time.After(3 * time.Second):
+		t.Fatal("config change not received")
+		return nil
+	}
+}
+
+func TestFileInterceptSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "intercept.yaml")
+	if err := os.WriteFile(path, []byte("switch: true\nradio: 10\nsub_rules:\n  - name: login\n    path: /auth/login\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	src := NewFileInterceptSource(path)
+	conf, err := src.Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !conf.Switch || conf.Radio != 10 || len(conf.SubRules) != 1 || conf.SubRules[0].Path != "/auth/login" {
+		t.Errorf("Load() = %+v", conf)
+	}
+
+	changes, stop := watchInterceptSource(t, src)
+	defer stop()
+	// 等待 watcher 开始监听
+	time.Sleep(100 * time.Millisecond)
+	if err := os.WriteFile(path, []byte("switch: false\nradio: 20\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if conf := waitInterceptConfig(t, changes); conf.Switch || conf.Radio != 20 {
+		t.Errorf("Watch() = %+v", conf)
+	}
+}
+
+func TestKratosConfigInterceptSource(t *testing.T) {
+	mem := newMemoryConfigSource("json", []byte(`{"intercept":{"switch":true,"radio":10}}`))
+	src := NewKratosConfigInterceptSource(mem, "intercept")
+	conf, err := src.Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !conf.Switch || conf.Radio != 10 {
+		t.Errorf("Load() = %+v", conf)
+	}
+
+	changes, stop := watchInterceptSource(t, src)
+	defer stop()
+	// 等待 Watch 注册 key 的回调
+	time.Sleep(100 * time.Millisecond)
+	mem.update([]byte(`{"intercept":{"switch":false,"radio":30}}`))
+	if conf := waitInterceptConfig(t, changes); conf.Switch || conf.Radio != 30 {
+		t.Errorf("Watch() = %+v", conf)
+	}
+}
+
+// failingConfigSource 前 fails 次 Load 返回错误
+type failingConfigSource struct {
+	*memoryConfigSource
+	fails int
+}
+
+func (s *failingConfigSource) Load() ([]*config.KeyValue, error) {
+	if s.fails > 0 {
+		s.fails--
+		return nil, errors.New("config unavailable")
+	}
+	return s.memoryConfigSource.Load()
+}
+
+// 首次加载失败不应缓存错误
+func TestKratosConfigInterceptSourceLoadRetry(t *testing.T) {
+	mem := &failingConfigSource{
+		memoryConfigSource: newMemoryConfigSource("json", []byte(`{"intercept":{"switch":true,"radio":10}}`)),
+		fails:              1,
+	}
+	src := NewKratosConfigInterceptSource(mem, "intercept")
+	if _, err := src.Load(context.Background()); err == nil {
+		t.Fatal("first Load should fail")
+	}
+	conf, err := src.Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !conf.Switch || conf.Radio != 10 {
+		t.Errorf("Load() = %+v", conf)
+	}
+}
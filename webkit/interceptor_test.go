@@ -0,0 +1,296 @@
+package webkit
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+	"google.golang.org/grpc/metadata"
+)
+
+var initTestMetrics sync.Once
+
+// testHTTPTransport 实现 khttp.Transporter，用于在无 server 的情况下调用中间件
+type testHTTPTransport struct {
+	req *http.Request
+}
+
+func (t *testHTTPTransport) Kind() transport.Kind            { return transport.KindHTTP }
+func (t *testHTTPTransport) Endpoint() string                { return "" }
+func (t *testHTTPTransport) Operation() string               { return t.req.URL.Path }
+func (t *testHTTPTransport) RequestHeader() transport.Header { return headerCarrier(t.req.Header) }
+func (t *testHTTPTransport) ReplyHeader() transport.Header   { return headerCarrier(http.Header{}) }
+func (t *testHTTPTransport) Request() *http.Request          { return t.req }
+func (t *testHTTPTransport) PathTemplate() string            { return t.req.URL.Path }
+
+type headerCarrier http.Header
+
+func (hc headerCarrier) Get(key string) string      { return http.Header(hc).Get(key) }
+func (hc headerCarrier) Set(key, value string)      { http.Header(hc).Set(key, value) }
+func (hc headerCarrier) Add(key, value string)      { http.Header(hc).Add(key, value) }
+func (hc headerCarrier) Values(key string) []string { return http.Header(hc).Values(key) }
+func (hc headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(hc))
+	for k := range hc {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestTrafficInterceptorMiddleware(t *testing.T) {
+	initTestMetrics.Do(func() {
+		if err := InitMetrics("webkit_test"); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	secret := "secret"
+	now := time.Now()
+	conf := &InterceptConfig{
+		Switch: true,
+		Radio:  -1,
+		SubRules: []SubRuleConfig{
+			{Radio: 100, Match: &RuleExpr{Field: RuleFieldUA, Op: RuleOpContains, Values: []string{"curl"}}},
+		},
+	}
+	newInterceptor := func(clock time.Time) *TrafficInterceptor {
+		ti, err := NewTrafficInterceptor(
+			WithInterceptServerName("test"),
+			WithInterceptConfig(conf),
+			WithInterceptSignConfig(&SignConfig{Secret: secret, Enabled: true}),
+			WithInterceptClock(func() time.Time { return clock }),
+			WithInterceptRandSource(rand.NewSource(1)),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(ti.Stop)
+		return ti
+	}
+
+	tests := []struct {
+		name      string
+		clock     time.Time
+		ua        string
+		signed    bool
+		wantBlock bool
+	}{
+		{"signed", now, "curl/8.0", true, false},
+		{"unsigned normal ua", now, "Mozilla/5.0", false, false},
+		{"unsigned matched ua", now, "curl/8.0", false, true},
+		{"signed but clock drifted", now.Add(time.Hour), "curl/8.0", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/probe", nil)
+			req.Header.Set("User-Agent", tt.ua)
+			if tt.signed {
+				req.Header.Set("Request-Time", GenerateRequestTime(secret, 8))
+			}
+			ctx := transport.NewServerContext(context.Background(), &testHTTPTransport{req: req})
+
+			called := false
+			handler := newInterceptor(tt.clock).Middleware()(func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return "ok", nil
+			})
+			_, err := handler(ctx, nil)
+			if blocked := err != nil; blocked != tt.wantBlock {
+				t.Errorf("blocked = %v, want %v", blocked, tt.wantBlock)
+			}
+			if called == tt.wantBlock {
+				t.Errorf("handler called = %v, want %v", called, !tt.wantBlock)
+			}
+		})
+	}
+}
+
+type countingNotifier struct {
+	count int
+}
+
+func (n *countingNotifier) SendBizMessage(context.Context, string, string) {
+	n.count++
+}
+
+func TestTrafficInterceptorActions(t *testing.T) {
+	now := time.Now()
+	notifier := &countingNotifier{}
+	ti, err := NewTrafficInterceptor(
+		WithInterceptClock(func() time.Time { return now }),
+		WithInterceptNotifier(notifier),
+		WithInterceptChallenge(func(ctx context.Context) bool {
+			return GetHeader(ctx, "Captcha-Token") == "ok"
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compile := func(action *RuleAction) *compiledSubRule {
+		rule, err := compileSubRule(0, SubRuleConfig{Rule: "*", Action: action})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rule
+	}
+	feature := &requestFeature{path: "/probe", uid: "1"}
+	ctx := context.Background()
+
+	block := compile(&RuleAction{Type: RuleActionBlock, Code: 451, Reason: "BLOCKED"})
+	if err := ti.applyAction(ctx, block, feature); kerrors.Code(err) != 451 {
+		t.Errorf("block code = %d, want 451", kerrors.Code(err))
+	}
+	if err := ti.applyAction(ctx, compile(&RuleAction{Type: RuleActionShadow}), feature); err != nil {
+		t.Errorf("shadow should not block: %v", err)
+	}
+
+	notify := compile(&RuleAction{Type: RuleActionNotify})
+	for i := 0; i < 3; i++ {
+		if err := ti.applyAction(ctx, notify, feature); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if notifier.count != 1 {
+		t.Errorf("notify count = %d, want 1", notifier.count)
+	}
+
+	captcha := compile(&RuleAction{Type: RuleActionCaptcha})
+	if err := ti.applyAction(ctx, captcha, feature); kerrors.Reason(err) != "CAPTCHA_REQUIRED" {
+		t.Errorf("captcha reason = %s", kerrors.Reason(err))
+	}
+	req := httptest.NewRequest(http.MethodGet, "/probe", nil)
+	req.Header.Set("Captcha-Token", "ok")
+	passCtx := transport.NewServerContext(ctx, &testHTTPTransport{req: req})
+	if err := ti.applyAction(passCtx, captcha, feature); err != nil {
+		t.Errorf("captcha with passed challenge should not block: %v", err)
+	}
+
+	limit := compile(&RuleAction{Type: RuleActionRateLimit, Limit: 2, Window: 60})
+	for i := 0; i < 3; i++ {
+		err := ti.applyAction(ctx, limit, feature)
+		if blocked := err != nil; blocked != (i == 2) {
+			t.Errorf("rate_limit request %d blocked = %v", i, blocked)
+		}
+	}
+
+	if _, err := compileSubRule(0, SubRuleConfig{Action: &RuleAction{Type: "drop"}}); err == nil {
+		t.Error("unknown action should fail to compile")
+	}
+}
+
+func TestStickySampling(t *testing.T) {
+	newInterceptor := func(seed uint64) *TrafficInterceptor {
+		ti, err := NewTrafficInterceptor(WithInterceptHashSeed(seed))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ti
+	}
+	rule, err := compileSubRule(0, SubRuleConfig{Rule: "*", Radio: 30, StickyKey: StickyKeyUID, Action: &RuleAction{Type: RuleActionBlock}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, b, c := newInterceptor(1), newInterceptor(1), newInterceptor(2)
+	hits, diff := 0, 0
+	for i := 0; i < 10000; i++ {
+		feature := &requestFeature{uid: strconv.Itoa(i)}
+		got := a.sampled(rule, feature)
+		// 同一客户端重复请求、同种子的其他实例结果一致
+		if a.sampled(rule, feature) != got || b.sampled(rule, feature) != got {
+			t.Fatalf("uid %d sampling is not sticky", i)
+		}
+		if c.sampled(rule, feature) != got {
+			diff++
+		}
+		if got {
+			hits++
+		}
+	}
+	if hits < 2800 || hits > 3200 {
+		t.Errorf("hits = %d, want about 3000", hits)
+	}
+	if diff == 0 {
+		t.Error("different seed should reshuffle clients")
+	}
+
+	if _, err := compileStickyKey("cookie"); err == nil {
+		t.Error("unknown sticky key should fail")
+	}
+}
+
+// testGRPCTransport 模拟 gRPC server transport
+type testGRPCTransport struct {
+	operation string
+	md        metadata.MD
+}
+
+func (t *testGRPCTransport) Kind() transport.Kind            { return transport.KindGRPC }
+func (t *testGRPCTransport) Endpoint() string                { return "" }
+func (t *testGRPCTransport) Operation() string               { return t.operation }
+func (t *testGRPCTransport) RequestHeader() transport.Header { return headerCarrier(t.md) }
+func (t *testGRPCTransport) ReplyHeader() transport.Header   { return headerCarrier(http.Header{}) }
+
+func TestTrafficInterceptorGRPC(t *testing.T) {
+	initTestMetrics.Do(func() {
+		if err := InitMetrics("webkit_test"); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	secret := "secret"
+	conf := &InterceptConfig{
+		Switch: true,
+		Radio:  -1,
+		SubRules: []SubRuleConfig{{
+			Radio: 100,
+			Match: &RuleExpr{All: []*RuleExpr{
+				{Field: RuleFieldPath, Op: RuleOpPrefix, Values: []string{"/api.web.Auth/"}},
+				{Field: RuleFieldHost, Values: []string{"internal.example.com"}},
+				{Field: RuleFieldUA, Op: RuleOpContains, Values: []string{"grpc-go"}},
+			}},
+			Action: &RuleAction{Type: RuleActionBlock, Code: 403},
+		}},
+	}
+	ti, err := NewTrafficInterceptor(
+		WithInterceptConfig(conf),
+		WithInterceptSignConfig(&SignConfig{Secret: secret, Enabled: true}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		operation string
+		signed    bool
+		wantBlock bool
+	}{
+		{"signed", "/api.web.Auth/Login", true, false},
+		{"unsigned matched", "/api.web.Auth/Login", false, true},
+		{"unsigned other operation", "/api.web.Probe/Ping", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := metadata.Pairs(":authority", "internal.example.com", "user-agent", "grpc-go/1.77.0")
+			if tt.signed {
+				md.Set("request-time", GenerateRequestTime(secret, 8))
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), md)
+			ctx = transport.NewServerContext(ctx, &testGRPCTransport{operation: tt.operation, md: md})
+			_, err := ti.Middleware()(func(context.Context, interface{}) (interface{}, error) {
+				return "ok", nil
+			})(ctx, nil)
+			if blocked := err != nil; blocked != tt.wantBlock {
+				t.Errorf("blocked = %v, want %v", blocked, tt.wantBlock)
+			}
+		})
+	}
+}
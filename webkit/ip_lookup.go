@@ -107,3 +107,12 @@ func (geo *GeoIP) GetCountryByIp(ipStr string) (*geoip2.Country, error) {
 	}
 	return geo.cli.Country(ip)
 }
+
+// CountryCode 返回 IP 所属国家的 ISO 编码，解析失败返回空字符串，实现 CountryResolver
+func (geo *GeoIP) CountryCode(ipStr string) string {
+	country, err := geo.GetCountryByIp(ipStr)
+	if err != nil || country == nil {
+		return ""
+	}
+	return country.Country.IsoCode
+}
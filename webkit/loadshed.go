@@ -0,0 +1,388 @@
+package webkit
+
+import (
+	"context"
+	"math"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// LoadShedReason 过载丢弃错误的 reason，ErrorEncoder 映射为 503
+const LoadShedReason = "LOADSHED"
+
+var ErrLoadShed = kerrors.New(503, LoadShedReason, "service overloaded, please retry later")
+
+// Priority 请求优先级，过载时从低到高依次丢弃
+type Priority int
+
+const (
+	PriorityLow Priority = iota
+	PriorityNormal
+	PriorityHigh
+	PriorityCritical
+	// PriorityExempt 永不丢弃，用于探针等必须响应的接口
+	PriorityExempt
+)
+
+var priorityNames = map[Priority]string{
+	PriorityLow:      "low",
+	PriorityNormal:   "normal",
+	PriorityHigh:     "high",
+	PriorityCritical: "critical",
+	PriorityExempt:   "exempt",
+}
+
+func (p Priority) String() string {
+	if name, ok := priorityNames[p]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ParsePriority 解析优先级名称，兼容 proto 枚举的 PRIORITY_ 前缀
+func ParsePriority(name string) (Priority, bool) {
+	name = strings.TrimPrefix(strings.ToLower(name), "priority_")
+	for p, n := range priorityNames {
+		if n == name {
+			return p, true
+		}
+	}
+	return 0, false
+}
+
+// 各优先级可使用的并发额度占自适应并发上限的比例
+var defaultPriorityShares = map[Priority]float64{
+	PriorityLow:      0.5,
+	PriorityNormal:   0.75,
+	PriorityHigh:     0.9,
+	PriorityCritical: 1,
+}
+
+const (
+	defaultLoadShedInitialLimit = 100
+	defaultLoadShedMinLimit     = 20
+	defaultLoadShedMaxLimit     = 1000
+)
+
+// LoadShedConfig 过载保护配置
+type LoadShedConfig struct {
+	// Rules 按顺序匹配 operation 的优先级，优先于 proto 选项
+	Rules []LoadShedRule `json:"rules"`
+	// DefaultPriority 未匹配任何规则的请求优先级，默认 normal
+	DefaultPriority string `json:"default_priority,omitempty"`
+	// InitialLimit/MinLimit/MaxLimit 自适应并发上限的初始值与范围，默认 100、20、1000
+	InitialLimit int `json:"initial_limit,omitempty"`
+	MinLimit     int `json:"min_limit,omitempty"`
+	MaxLimit     int `json:"max_limit,omitempty"`
+}
+
+// LoadShedRule operation 的优先级，Operations 以 * 结尾表示前缀匹配
+type LoadShedRule struct {
+	Operations []string `json:"operations"`
+	Priority   string   `json:"priority"`
+}
+
+// PriorityResolver 根据 operation 解析优先级，未声明时返回 false
+type PriorityResolver func(operation string) (Priority, bool)
+
+// ProtoPriorityResolver 从 rpc 方法的自定义选项读取优先级，选项可为枚举或字符串，例如：
+//
+//	extend google.protobuf.MethodOptions { Priority priority = 51001; }
+//	rpc healthReady (...) returns (...) { option (web.priority) = PRIORITY_EXEMPT; }
+func ProtoPriorityResolver(xt protoreflect.ExtensionType) PriorityResolver {
+	return func(operation string) (Priority, bool) {
+		name := protoreflect.FullName(strings.ReplaceAll(strings.TrimPrefix(operation, "/"), "/", "."))
+		if !name.IsValid() {
+			return 0, false
+		}
+		desc, err := protoregistry.GlobalFiles.FindDescriptorByName(name)
+		if err != nil {
+			return 0, false
+		}
+		method, ok := desc.(protoreflect.MethodDescriptor)
+		if !ok || method.Options() == nil || !proto.HasExtension(method.Options(), xt) {
+			return 0, false
+		}
+		switch v := proto.GetExtension(method.Options(), xt).(type) {
+		case string:
+			return ParsePriority(v)
+		case protoreflect.Enum:
+			value := v.Descriptor().Values().ByNumber(v.Number())
+			if value == nil {
+				return 0, false
+			}
+			return ParsePriority(string(value.Name()))
+		}
+		return 0, false
+	}
+}
+
+var (
+	// defaultLoadShedder LoadShedMiddleware 使用的默认实例，InitLoadShed 前为空，不做过载保护
+	defaultLoadShedder atomic.Pointer[LoadShedder]
+)
+
+// InitLoadShed 初始化默认过载保护实例
+func InitLoadShed(conf *LoadShedConfig, opts ...LoadShedderOption) error {
+	ls, err := NewLoadShedder(conf, opts...)
+	if err != nil {
+		return err
+	}
+	defaultLoadShedder.Store(ls)
+	return nil
+}
+
+// DefaultLoadShedder 返回默认过载保护实例，InitLoadShed 前返回 nil
+func DefaultLoadShedder() *LoadShedder {
+	return defaultLoadShedder.Load()
+}
+
+// LoadShedMiddleware 使用默认实例的过载保护中间件，InitLoadShed 后立即生效，之前直接放行
+func LoadShedMiddleware() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			ls := defaultLoadShedder.Load()
+			if ls == nil {
+				return handler(ctx, req)
+			}
+			return ls.handle(ctx, req, handler)
+		}
+	}
+}
+
+// ---------------- load shedder ----------------
+
+// LoadShedder 按优先级分层的自适应并发限制：根据请求耗时的变化调整并发上限，
+// 低优先级只能使用上限的一部分，过载时先于高优先级被丢弃
+type LoadShedder struct {
+	rules           []loadShedRule
+	defaultPriority Priority
+	resolver        PriorityResolver
+	shares          map[Priority]float64
+	now             func() time.Time
+
+	// priorities operation 解析结果缓存
+	priorities sync.Map
+	inflight   atomic.Int64
+	limit      *gradientLimit
+}
+
+type loadShedRule struct {
+	exact    map[string]struct{}
+	prefixes []string
+	priority Priority
+}
+
+// LoadShedderOption 过载保护选项
+type LoadShedderOption func(*LoadShedder)
+
+// WithLoadShedPriorityResolver 配置规则未命中时的优先级来源，通常为 ProtoPriorityResolver
+func WithLoadShedPriorityResolver(resolver PriorityResolver) LoadShedderOption {
+	return func(ls *LoadShedder) {
+		ls.resolver = resolver
+	}
+}
+
+// WithLoadShedShare 调整某一优先级可使用的并发额度比例（0~1）
+func WithLoadShedShare(priority Priority, share float64) LoadShedderOption {
+	return func(ls *LoadShedder) {
+		ls.shares[priority] = share
+	}
+}
+
+// WithLoadShedClock 替换时钟，仅用于测试
+func WithLoadShedClock(now func() time.Time) LoadShedderOption {
+	return func(ls *LoadShedder) {
+		ls.now = now
+	}
+}
+
+// NewLoadShedder 创建过载保护实例，conf 为 nil 时使用默认配置
+func NewLoadShedder(conf *LoadShedConfig, opts ...LoadShedderOption) (*LoadShedder, error) {
+	if conf == nil {
+		conf = &LoadShedConfig{}
+	}
+	ls := &LoadShedder{
+		defaultPriority: PriorityNormal,
+		shares:          make(map[Priority]float64, len(defaultPriorityShares)),
+		now:             time.Now,
+	}
+	for p, share := range defaultPriorityShares {
+		ls.shares[p] = share
+	}
+	if conf.DefaultPriority != "" {
+		p, ok := ParsePriority(conf.DefaultPriority)
+		if !ok {
+			return nil, errors.Errorf("unknown default priority: %q", conf.DefaultPriority)
+		}
+		ls.defaultPriority = p
+	}
+	for i, rule := range conf.Rules {
+		p, ok := ParsePriority(rule.Priority)
+		if !ok {
+			return nil, errors.Errorf("load shed rules[%d]: unknown priority: %q", i, rule.Priority)
+		}
+		compiled := loadShedRule{exact: make(map[string]struct{}), priority: p}
+		for _, op := range rule.Operations {
+			if strings.HasSuffix(op, "*") {
+				compiled.prefixes = append(compiled.prefixes, strings.TrimSuffix(op, "*"))
+			} else {
+				compiled.exact[op] = struct{}{}
+			}
+		}
+		ls.rules = append(ls.rules, compiled)
+	}
+	minLimit, maxLimit, initialLimit := conf.MinLimit, conf.MaxLimit, conf.InitialLimit
+	if minLimit <= 0 {
+		minLimit = defaultLoadShedMinLimit
+	}
+	if maxLimit <= 0 {
+		maxLimit = defaultLoadShedMaxLimit
+	}
+	if initialLimit <= 0 {
+		initialLimit = defaultLoadShedInitialLimit
+	}
+	if minLimit > maxLimit {
+		return nil, errors.Errorf("min_limit %d exceeds max_limit %d", minLimit, maxLimit)
+	}
+	ls.limit = newGradientLimit(float64(initialLimit), float64(minLimit), float64(maxLimit))
+	for _, opt := range opts {
+		opt(ls)
+	}
+	return ls, nil
+}
+
+// Middleware 过载保护中间件
+func (ls *LoadShedder) Middleware() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			return ls.handle(ctx, req, handler)
+		}
+	}
+}
+
+// Limit 当前的自适应并发上限
+func (ls *LoadShedder) Limit() int {
+	return int(ls.limit.get())
+}
+
+func (ls *LoadShedder) handle(ctx context.Context, req interface{}, handler middleware.Handler) (interface{}, error) {
+	operation := GetOperationFromContext(ctx)
+	priority := ls.priority(operation)
+	if priority == PriorityExempt {
+		return handler(ctx, req)
+	}
+	inflight := ls.inflight.Add(1)
+	defer ls.inflight.Add(-1)
+	if float64(inflight) > ls.limit.get()*ls.shares[priority] {
+		RecordMetricLoadShedWithCtx(ctx, operation, priority.String())
+		return nil, ErrLoadShed
+	}
+	start := ls.now()
+	reply, err := handler(ctx, req)
+	// 客户端取消的请求耗时不代表服务端负载
+	if ctx.Err() == nil {
+		ls.limit.update(ls.now().Sub(start), int(inflight))
+	}
+	return reply, err
+}
+
+func (ls *LoadShedder) priority(operation string) Priority {
+	if p, ok := ls.priorities.Load(operation); ok {
+		return p.(Priority)
+	}
+	p := ls.resolvePriority(operation)
+	ls.priorities.Store(operation, p)
+	return p
+}
+
+func (ls *LoadShedder) resolvePriority(operation string) Priority {
+	for _, rule := range ls.rules {
+		if _, ok := rule.exact[operation]; ok {
+			return rule.priority
+		}
+		for _, prefix := range rule.prefixes {
+			if strings.HasPrefix(operation, prefix) {
+				return rule.priority
+			}
+		}
+	}
+	if ls.resolver != nil {
+		if p, ok := ls.resolver(operation); ok {
+			return p
+		}
+	}
+	return ls.defaultPriority
+}
+
+// ---------------- gradient limit ----------------
+
+const (
+	gradientTolerance = 1.5
+	gradientSmoothing = 0.2
+	gradientWindow    = 100
+	gradientWarmup    = 10
+)
+
+// gradientLimit 参考 Netflix concurrency-limits 的 Gradient2 算法：
+// 以长期平均耗时为基线，短期耗时超过基线的 tolerance 倍时按比例收缩上限，否则缓慢增长
+type gradientLimit struct {
+	mu       sync.Mutex
+	limit    float64
+	minLimit float64
+	maxLimit float64
+	// longRTT 耗时的指数移动平均（纳秒），预热期内为算术平均
+	longRTT float64
+	samples int
+}
+
+func newGradientLimit(initial, minLimit, maxLimit float64) *gradientLimit {
+	return &gradientLimit{
+		limit:    math.Max(minLimit, math.Min(maxLimit, initial)),
+		minLimit: minLimit,
+		maxLimit: maxLimit,
+	}
+}
+
+func (g *gradientLimit) get() float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.limit
+}
+
+func (g *gradientLimit) update(rtt time.Duration, inflight int) {
+	shortRTT := float64(rtt)
+	if shortRTT <= 0 {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.samples++
+	if g.samples <= gradientWarmup {
+		g.longRTT += (shortRTT - g.longRTT) / float64(g.samples)
+	} else {
+		g.longRTT += (shortRTT - g.longRTT) * 2 / (gradientWindow + 1)
+	}
+	// 负载下降后基线快速回落，避免长期维持过高的基线
+	if g.longRTT/shortRTT > 2 {
+		g.longRTT *= 0.95
+	}
+	// 并发不足上限一半时耗时不反映容量，不调整上限
+	if float64(inflight) < g.limit/2 {
+		return
+	}
+	gradient := math.Max(0.5, math.Min(1, gradientTolerance*g.longRTT/shortRTT))
+	next := g.limit*gradient + math.Sqrt(g.limit)
+	next = g.limit*(1-gradientSmoothing) + next*gradientSmoothing
+	g.limit = math.Max(g.minLimit, math.Min(g.maxLimit, next))
+}
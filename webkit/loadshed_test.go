@@ -0,0 +1,124 @@
+package webkit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+func TestLoadShedderPriority(t *testing.T) {
+	ls, err := NewLoadShedder(&LoadShedConfig{
+		Rules: []LoadShedRule{
+			{Operations: []string{"/web.Probe/*"}, Priority: "exempt"},
+			{Operations: []string{"/web.Auth/LoginByWallet"}, Priority: "critical"},
+			{Operations: []string{"/web.Order/*"}, Priority: "high"},
+			{Operations: []string{"/web.Feed/*"}, Priority: "PRIORITY_LOW"},
+		},
+		InitialLimit: 4,
+		MinLimit:     4,
+		MaxLimit:     4,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	newCtx := func(operation string) context.Context {
+		req := httptest.NewRequest(http.MethodGet, operation, nil)
+		return transport.NewServerContext(context.Background(), &testHTTPTransport{req: req})
+	}
+
+	// 3 个 normal 请求占用并发
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	started := make(chan struct{}, 3)
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = ls.Middleware()(func(context.Context, interface{}) (interface{}, error) {
+				started <- struct{}{}
+				<-release
+				return nil, nil
+			})(newCtx("/web.User/Info"), nil)
+		}()
+		<-started
+	}
+
+	tests := []struct {
+		operation string
+		wantShed  bool
+	}{
+		{"/web.Feed/List", true},
+		{"/web.User/Info", true},
+		{"/web.Order/Create", true},
+		{"/web.Auth/LoginByWallet", false},
+		{"/web.Probe/healthReady", false},
+	}
+	for _, tt := range tests {
+		_, err := ls.Middleware()(func(context.Context, interface{}) (interface{}, error) {
+			return "ok", nil
+		})(newCtx(tt.operation), nil)
+		if shed := kerrors.Reason(err) == LoadShedReason; shed != tt.wantShed {
+			t.Errorf("%s shed = %v, want %v", tt.operation, shed, tt.wantShed)
+		}
+	}
+	close(release)
+	wg.Wait()
+
+	if _, err := NewLoadShedder(&LoadShedConfig{Rules: []LoadShedRule{{Priority: "urgent"}}}); err == nil {
+		t.Error("unknown priority should fail")
+	}
+}
+
+func TestGradientLimit(t *testing.T) {
+	g := newGradientLimit(100, 10, 200)
+	for i := 0; i < 50; i++ {
+		g.update(10*time.Millisecond, 100)
+	}
+	grown := g.get()
+	if grown <= 100 {
+		t.Fatalf("limit = %v, should grow under stable latency", grown)
+	}
+	for i := 0; i < 50; i++ {
+		g.update(100*time.Millisecond, int(g.get()))
+	}
+	if g.get() >= grown {
+		t.Errorf("limit = %v, should shrink when latency rises (was %v)", g.get(), grown)
+	}
+	// 并发很低时不调整
+	before := g.get()
+	g.update(time.Second, 1)
+	if g.get() != before {
+		t.Errorf("limit changed while app limited: %v -> %v", before, g.get())
+	}
+}
+
+// 未配置时不启用过载保护
+func TestLoadShedDisabledByDefault(t *testing.T) {
+	if ls := DefaultLoadShedder(); ls != nil {
+		t.Fatalf("DefaultLoadShedder() = %v before InitLoadShed", ls)
+	}
+	handler := LoadShedMiddleware()(func(context.Context, interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	if reply, err := handler(context.Background(), nil); err != nil || reply != "ok" {
+		t.Errorf("LoadShedMiddleware() = %v, %v", reply, err)
+	}
+
+	ls, err := NewLoadShedder(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := len(PrepareMiddleWare())
+	if n := len(PrepareMiddleWare(WithMiddlewareLoadShedder(nil))); n != base {
+		t.Errorf("nil load shedder added %d middlewares", n-base)
+	}
+	if n := len(PrepareMiddleWare(WithMiddlewareLoadShedder(ls))); n != base+1 {
+		t.Errorf("PrepareMiddleWare() = %d middlewares, want %d", n, base+1)
+	}
+}
@@ -27,9 +27,9 @@ func ServerLogging() middleware.Middleware {
 			if se, ok = err.(*errors.Error); !ok {
 				// 兼容errors.New("...")
 				if err != nil {
-					se = errors.Newf(5000, err.Error(), err.Error())
+					se = errors.New(5000, err.Error(), err.Error())
 				} else {
-					se = errors.Newf(0, "", "")
+					se = errors.New(0, "", "")
 				}
 			}
 			args := FirstN(extractArgs(req), 500)
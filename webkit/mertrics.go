@@ -2,12 +2,22 @@ package webkit
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/pkg/errors"
+	promclient "github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 )
 
 var (
@@ -23,32 +33,310 @@ var (
 	_alarmStatsMetric     metric.Int64Counter
 	_platformMetric       metric.Int64Counter
 	_methodDurationMetric metric.Float64Histogram
+	_methodOutcomeMetric  metric.Float64Histogram
+	_metricSignKey        metric.Int64Counter
+	_metricInterceptAct   metric.Int64Counter
+	_metricLoadShed       metric.Int64Counter
+	_metricOutboxPublish  metric.Int64Counter
 )
 
-// InitMetrics 初始化指标
-func InitMetrics(name string) error {
-	// 创建 Prometheus exporter
-	exporter, err := prometheus.New()
-	if err != nil {
-		return err
+// 指标导出方式
+const (
+	// MetricsExporterPrometheus 注册到 Prometheus 默认 registry，由 /metrics 拉取
+	MetricsExporterPrometheus = "prometheus"
+	// MetricsExporterOTLPGRPC 通过 OTLP gRPC 定时推送
+	MetricsExporterOTLPGRPC = "otlp_grpc"
+	// MetricsExporterOTLPHTTP 通过 OTLP HTTP 定时推送
+	MetricsExporterOTLPHTTP = "otlp_http"
+
+	defaultMetricsPushInterval = 60 * time.Second
+)
+
+var (
+	// meterProvider InitMetrics 创建的 provider，用于 ShutdownMetrics
+	meterProvider *sdkmetric.MeterProvider
+	// metricsServiceName InitMetrics 的 name，Measure 记录的 service_name
+	metricsServiceName string
+	// metricsPrometheus InitMetrics 是否启用了 prometheus 导出，决定进程 CPU、文件描述符指标由谁提供
+	metricsPrometheus bool
+	// metricsCollectors InitMetrics 注册到 Prometheus 的 collector，ShutdownMetrics 时注销
+	metricsCollectors *metricsRegisterer
+)
+
+func init() {
+	resetMetrics()
+}
+
+// resetMetrics 所有指标恢复为 no-op，未初始化时单测与脚本中调用 Record* 不会 panic
+func resetMetrics() {
+	meter = noop.NewMeterProvider().Meter("")
+	_ = initMetrics()
+}
+
+type metricsOptions struct {
+	exporters    []string
+	otlpEndpoint string
+	otlpInsecure bool
+	otlpHeaders  map[string]string
+	pushInterval time.Duration
+	version      string
+	env          string
+	attributes   []attribute.KeyValue
+	buckets      map[string][]float64
+	registerer   promclient.Registerer
+	readers      []sdkmetric.Reader
+}
+
+// MetricsOption 指标初始化选项
+type MetricsOption func(*metricsOptions)
+
+// WithMetricsExporters 指标导出方式，可同时启用多个，默认仅 prometheus
+func WithMetricsExporters(exporters ...string) MetricsOption {
+	return func(o *metricsOptions) {
+		o.exporters = exporters
+	}
+}
+
+// WithMetricsOTLPEndpoint OTLP 推送地址，host:port 或完整 URL，insecure 为 true 时不使用 TLS
+func WithMetricsOTLPEndpoint(endpoint string, insecure bool) MetricsOption {
+	return func(o *metricsOptions) {
+		o.otlpEndpoint = endpoint
+		o.otlpInsecure = insecure
+	}
+}
+
+// WithMetricsOTLPHeaders OTLP 推送附带的请求头，例如鉴权 token
+func WithMetricsOTLPHeaders(headers map[string]string) MetricsOption {
+	return func(o *metricsOptions) {
+		o.otlpHeaders = headers
+	}
+}
+
+// WithMetricsPushInterval OTLP 推送间隔，默认60秒
+func WithMetricsPushInterval(interval time.Duration) MetricsOption {
+	return func(o *metricsOptions) {
+		o.pushInterval = interval
+	}
+}
+
+// WithMetricsResource 资源属性中的服务版本与环境，服务名取 InitMetrics 的 name
+func WithMetricsResource(version, env string, attrs ...attribute.KeyValue) MetricsOption {
+	return func(o *metricsOptions) {
+		o.version = version
+		o.env = env
+		o.attributes = append(o.attributes, attrs...)
+	}
+}
+
+// WithMetricsBuckets 覆盖指定直方图的分桶，例如 server_requests_duration
+func WithMetricsBuckets(name string, buckets ...float64) MetricsOption {
+	return func(o *metricsOptions) {
+		if o.buckets == nil {
+			o.buckets = make(map[string][]float64)
+		}
+		o.buckets[name] = buckets
+	}
+}
+
+// WithMetricsPrometheusRegisterer prometheus 导出使用的 registry，默认为全局 registry
+func WithMetricsPrometheusRegisterer(registerer promclient.Registerer) MetricsOption {
+	return func(o *metricsOptions) {
+		o.registerer = registerer
+	}
+}
+
+// WithMetricsReader 追加自定义 Reader，例如测试使用的 ManualReader
+func WithMetricsReader(reader sdkmetric.Reader) MetricsOption {
+	return func(o *metricsOptions) {
+		o.readers = append(o.readers, reader)
+	}
+}
+
+// InitMetrics 初始化指标并注册为全局 MeterProvider，默认使用 Prometheus 导出
+//
+// 重复调用时在新 provider 创建成功后关闭上一次的 provider 并注销其 Prometheus collector；
+// 失败时清理已创建的 exporter，保留上一次的 provider，可修正配置后重试
+func InitMetrics(name string, opts ...MetricsOption) error {
+	o := &metricsOptions{
+		exporters:    []string{MetricsExporterPrometheus},
+		pushInterval: defaultMetricsPushInterval,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	// exporter 的 Shutdown 不会注销 collector，记录下来由 ShutdownMetrics 注销
+	registerer := &metricsRegisterer{Registerer: o.registerer}
+	if registerer.Registerer == nil {
+		registerer.Registerer = promclient.DefaultRegisterer
+	}
+	o.registerer = registerer
+
+	providerOpts := []sdkmetric.Option{sdkmetric.WithResource(metricsResource(name, o))}
+	for _, reader := range o.readers {
+		providerOpts = append(providerOpts, sdkmetric.WithReader(reader))
+	}
+	var readers []sdkmetric.Reader
+	for _, exporter := range o.exporters {
+		reader, err := newMetricsReader(exporter, o)
+		if err != nil {
+			for _, reader := range readers {
+				_ = reader.Shutdown(context.Background())
+			}
+			registerer.unregister()
+			return errors.Wrapf(err, "init %s metrics exporter", exporter)
+		}
+		readers = append(readers, reader)
+		providerOpts = append(providerOpts, sdkmetric.WithReader(reader))
+	}
+	for instrument, buckets := range o.buckets {
+		providerOpts = append(providerOpts, sdkmetric.WithView(sdkmetric.NewView(
+			sdkmetric.Instrument{Name: instrument},
+			sdkmetric.Stream{Aggregation: sdkmetric.AggregationExplicitBucketHistogram{Boundaries: buckets}},
+		)))
 	}
 
 	// 创建 MeterProvider
-	provider := sdkmetric.NewMeterProvider(
-		sdkmetric.WithReader(exporter),
-	)
+	provider := sdkmetric.NewMeterProvider(providerOpts...)
 
-	// 获取 Meter
+	// 获取 Meter 并初始化各个指标，失败时指标恢复到上一次的 Meter
+	prevMeter := meter
 	meter = provider.Meter(name)
-
-	// 初始化各个指标
 	if err := initMetrics(); err != nil {
+		meter = prevMeter
+		_ = initMetrics()
+		_ = provider.Shutdown(context.Background())
+		registerer.unregister()
 		return err
 	}
+	otel.SetMeterProvider(provider)
+
+	prevProvider, prevRegisterer := meterProvider, metricsCollectors
+	meterProvider = provider
+	metricsCollectors = registerer
+	metricsServiceName = name
+	metricsPrometheus = false
+	for _, exporter := range o.exporters {
+		if exporter == MetricsExporterPrometheus {
+			metricsPrometheus = true
+		}
+	}
+	if prevRegisterer != nil {
+		prevRegisterer.unregister()
+	}
+	if prevProvider != nil {
+		if err := prevProvider.Shutdown(context.Background()); err != nil {
+			log.Warnf("shutdown previous metrics provider: %+v", err)
+		}
+	}
+	return nil
+}
+
+// ShutdownMetrics 推送剩余指标并关闭 MeterProvider，应在进程退出前调用，之后指标恢复为 no-op
+func ShutdownMetrics(ctx context.Context) error {
+	if meterProvider == nil {
+		return nil
+	}
+	if metricsCollectors != nil {
+		metricsCollectors.unregister()
+		metricsCollectors = nil
+	}
+	err := meterProvider.Shutdown(ctx)
+	meterProvider = nil
+	metricsPrometheus = false
+	resetMetrics()
+	return err
+}
+
+// metricsRegisterer 记录 prometheus exporter 注册的 collector，用于关闭 provider 时注销
+type metricsRegisterer struct {
+	promclient.Registerer
+	collectors []promclient.Collector
+}
 
+func (r *metricsRegisterer) Register(c promclient.Collector) error {
+	if err := r.Registerer.Register(c); err != nil {
+		return err
+	}
+	r.collectors = append(r.collectors, c)
 	return nil
 }
 
+func (r *metricsRegisterer) MustRegister(cs ...promclient.Collector) {
+	for _, c := range cs {
+		if err := r.Register(c); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func (r *metricsRegisterer) unregister() {
+	for _, c := range r.collectors {
+		r.Registerer.Unregister(c)
+	}
+	r.collectors = nil
+}
+
+func metricsResource(name string, o *metricsOptions) *resource.Resource {
+	attrs := []attribute.KeyValue{semconv.ServiceNameKey.String(name)}
+	if o.version != "" {
+		attrs = append(attrs, semconv.ServiceVersionKey.String(o.version))
+	}
+	if o.env != "" {
+		attrs = append(attrs, attribute.String("env", o.env))
+	}
+	attrs = append(attrs, o.attributes...)
+	return resource.NewSchemaless(attrs...)
+}
+
+func newMetricsReader(exporter string, o *metricsOptions) (sdkmetric.Reader, error) {
+	ctx := context.Background()
+	switch exporter {
+	case MetricsExporterPrometheus:
+		var promOpts []prometheus.Option
+		if o.registerer != nil {
+			promOpts = append(promOpts, prometheus.WithRegisterer(o.registerer))
+		}
+		return prometheus.New(promOpts...)
+	case MetricsExporterOTLPGRPC:
+		var grpcOpts []otlpmetricgrpc.Option
+		if strings.Contains(o.otlpEndpoint, "://") {
+			grpcOpts = append(grpcOpts, otlpmetricgrpc.WithEndpointURL(o.otlpEndpoint))
+		} else if o.otlpEndpoint != "" {
+			grpcOpts = append(grpcOpts, otlpmetricgrpc.WithEndpoint(o.otlpEndpoint))
+		}
+		if o.otlpInsecure {
+			grpcOpts = append(grpcOpts, otlpmetricgrpc.WithInsecure())
+		}
+		if len(o.otlpHeaders) > 0 {
+			grpcOpts = append(grpcOpts, otlpmetricgrpc.WithHeaders(o.otlpHeaders))
+		}
+		exp, err := otlpmetricgrpc.New(ctx, grpcOpts...)
+		if err != nil {
+			return nil, err
+		}
+		return sdkmetric.NewPeriodicReader(exp, sdkmetric.WithInterval(o.pushInterval)), nil
+	case MetricsExporterOTLPHTTP:
+		var httpOpts []otlpmetrichttp.Option
+		if strings.Contains(o.otlpEndpoint, "://") {
+			httpOpts = append(httpOpts, otlpmetrichttp.WithEndpointURL(o.otlpEndpoint))
+		} else if o.otlpEndpoint != "" {
+			httpOpts = append(httpOpts, otlpmetrichttp.WithEndpoint(o.otlpEndpoint))
+		}
+		if o.otlpInsecure {
+			httpOpts = append(httpOpts, otlpmetrichttp.WithInsecure())
+		}
+		if len(o.otlpHeaders) > 0 {
+			httpOpts = append(httpOpts, otlpmetrichttp.WithHeaders(o.otlpHeaders))
+		}
+		exp, err := otlpmetrichttp.New(ctx, httpOpts...)
+		if err != nil {
+			return nil, err
+		}
+		return sdkmetric.NewPeriodicReader(exp, sdkmetric.WithInterval(o.pushInterval)), nil
+	}
+	return nil, errors.Errorf("unknown metrics exporter: %q", exporter)
+}
+
 func initMetrics() error {
 	var err error
 
@@ -128,6 +416,53 @@ func initMetrics() error {
 		return err
 	}
 
+	// 9. 签名密钥使用计数器
+	_metricSignKey, err = meter.Int64Counter(
+		"server_requests_sign_key",
+		metric.WithDescription("The usage of request signature keys"),
+	)
+	if err != nil {
+		return err
+	}
+
+	// 10. 拦截动作计数器
+	_metricInterceptAct, err = meter.Int64Counter(
+		"server_requests_intercept_action",
+		metric.WithDescription("The outcome of interception rule actions"),
+	)
+	if err != nil {
+		return err
+	}
+
+	// 11. 过载丢弃计数器
+	_metricLoadShed, err = meter.Int64Counter(
+		"server_requests_shed",
+		metric.WithDescription("The number of requests shed by priority under overload"),
+	)
+	if err != nil {
+		return err
+	}
+
+	// 12. 发件箱投递计数器
+	_metricOutboxPublish, err = meter.Int64Counter(
+		"outbox_published_total",
+		metric.WithDescription("The number of outbox messages relayed by topic and result"),
+	)
+	if err != nil {
+		return err
+	}
+
+	// 13. 按执行结果区分的方法时长直方图，Measure 使用，与 alarm_platform_method_cost 的标签集不同故单独命名
+	_methodOutcomeMetric, err = meter.Float64Histogram(
+		"alarm_platform_method_duration_seconds",
+		metric.WithDescription("Method execution duration by outcome"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10),
+	)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -153,6 +488,79 @@ func RecordMetricInterceptWithCtx(ctx context.Context, serverName, path, hasSign
 	)
 }
 
+func RecordMetricSignKey(serverName, kid, version, result string) {
+	RecordMetricSignKeyWithCtx(nil, serverName, kid, version, result)
+}
+func RecordMetricSignKeyWithCtx(ctx context.Context, serverName, kid, version, result string) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	_metricSignKey.Add(
+		ctx,
+		1,
+		metric.WithAttributes(
+			attribute.String("server_name", serverName),
+			attribute.String("kid", kid),
+			attribute.String("version", version),
+			attribute.String("result", result),
+		),
+	)
+}
+
+func RecordMetricInterceptAction(serverName, path, rule, action, outcome string) {
+	RecordMetricInterceptActionWithCtx(nil, serverName, path, rule, action, outcome)
+}
+func RecordMetricInterceptActionWithCtx(ctx context.Context, serverName, path, rule, action, outcome string) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	_metricInterceptAct.Add(
+		ctx,
+		1,
+		metric.WithAttributes(
+			attribute.String("server_name", serverName),
+			attribute.String("path", path),
+			attribute.String("rule", rule),
+			attribute.String("action", action),
+			attribute.String("outcome", outcome),
+		),
+	)
+}
+
+func RecordMetricLoadShed(operation, priority string) {
+	RecordMetricLoadShedWithCtx(nil, operation, priority)
+}
+func RecordMetricLoadShedWithCtx(ctx context.Context, operation, priority string) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	_metricLoadShed.Add(
+		ctx,
+		1,
+		metric.WithAttributes(
+			attribute.String("operation", operation),
+			attribute.String("priority", priority),
+		),
+	)
+}
+
+func RecordMetricOutboxPublish(topic, result string) {
+	RecordMetricOutboxPublishWithCtx(nil, topic, result)
+}
+func RecordMetricOutboxPublishWithCtx(ctx context.Context, topic, result string) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	_metricOutboxPublish.Add(
+		ctx,
+		1,
+		metric.WithAttributes(
+			attribute.String("topic", topic),
+			attribute.String("result", result),
+		),
+	)
+}
+
 func RecordMetricBotInterceptor(operation, block, blockInterceptor, success, headerExist, verifySuccess, interceptIfVerifyFail string) {
 	RecordMetricBotInterceptorWithCtx(nil, operation, block, blockInterceptor, success, headerExist, verifySuccess, interceptIfVerifyFail)
 }
@@ -261,3 +669,20 @@ func RecordMethodDurationMetricWithCtx(ctx context.Context, serviceName, operati
 		),
 	)
 }
+
+// RecordMethodOutcomeMetricWithCtx 记录方法耗时（秒）及执行结果到 alarm_platform_method_duration_seconds，outcome 取值见 MeasureOutcome*
+func RecordMethodOutcomeMetricWithCtx(ctx context.Context, serviceName, operation, proc, outcome string, duration float64) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	_methodOutcomeMetric.Record(
+		ctx,
+		duration,
+		metric.WithAttributes(
+			attribute.String("service_name", serviceName),
+			attribute.String("operation", operation),
+			attribute.String("proc", proc),
+			attribute.String("outcome", outcome),
+		),
+	)
+}
@@ -0,0 +1,159 @@
+package webkit
+
+import (
+	"context"
+	"testing"
+
+	promclient "github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestInitMetrics(t *testing.T) {
+	if err := InitMetrics("webkit_test", WithMetricsExporters("statsd")); err == nil {
+		t.Error("unknown exporter should fail")
+	}
+
+	reader := sdkmetric.NewManualReader()
+	err := InitMetrics("webkit_test",
+		WithMetricsExporters(),
+		WithMetricsReader(reader),
+		WithMetricsResource("v1.0.0", "test"),
+		WithMetricsBuckets("alarm_platform_method_cost", 0.1, 1),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := ShutdownMetrics(context.Background()); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	RecordAlarmStatsMetric("svc", "fn")
+	_methodDurationMetric.Record(context.Background(), 0.5)
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := rm.Resource.Set().Value("env"); !ok || v.AsString() != "test" {
+		t.Errorf("resource env = %v", v)
+	}
+	if v, _ := rm.Resource.Set().Value(attribute.Key("service.version")); v.AsString() != "v1.0.0" {
+		t.Errorf("resource service.version = %v", v)
+	}
+	found := map[string]bool{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			found[m.Name] = true
+			if m.Name != "alarm_platform_method_cost" {
+				continue
+			}
+			hist := m.Data.(metricdata.Histogram[float64])
+			if got := hist.DataPoints[0].Bounds; len(got) != 2 || got[0] != 0.1 || got[1] != 1 {
+				t.Errorf("buckets = %v, want [0.1 1]", got)
+			}
+		}
+	}
+	for _, name := range []string{"alarm_stats_service_function_total", "alarm_platform_method_cost"} {
+		if !found[name] {
+			t.Errorf("metric %s not collected", name)
+		}
+	}
+}
+
+// 重复初始化时上一次的 Prometheus 导出不应继续输出
+func TestInitMetricsTwice(t *testing.T) {
+	registry := promclient.NewRegistry()
+	for i := 0; i < 2; i++ {
+		if err := InitMetrics("webkit_test", WithMetricsPrometheusRegisterer(registry)); err != nil {
+			t.Fatal(err)
+		}
+		RecordAlarmStatsMetric("svc", "fn")
+	}
+	defer func() {
+		if err := ShutdownMetrics(context.Background()); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("gather: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != "alarm_stats_service_function_total" {
+			continue
+		}
+		if n := len(family.GetMetric()); n != 1 {
+			t.Fatalf("series = %d, want 1", n)
+		}
+		if v := family.GetMetric()[0].GetCounter().GetValue(); v != 1 {
+			t.Errorf("value = %v, want 1", v)
+		}
+		return
+	}
+	t.Error("alarm_stats_service_function_total not gathered")
+}
+
+// 初始化失败时保留上一次的 provider、注销已注册的 collector，修正后可重试
+func TestInitMetricsRetry(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	if err := InitMetrics("webkit_test", WithMetricsExporters(), WithMetricsReader(reader)); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := ShutdownMetrics(context.Background()); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	registry := promclient.NewRegistry()
+	err := InitMetrics("webkit_test",
+		WithMetricsPrometheusRegisterer(registry),
+		WithMetricsExporters(MetricsExporterPrometheus, "statsd"),
+	)
+	if err == nil {
+		t.Fatal("unknown exporter should fail")
+	}
+	RecordAlarmStatsMetric("svc", "fn")
+	if families, err := registry.Gather(); err != nil || len(families) != 0 {
+		t.Errorf("gather after failure = %d families, %v", len(families), err)
+	}
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("previous provider: %v", err)
+	}
+	found := false
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			found = found || m.Name == "alarm_stats_service_function_total"
+		}
+	}
+	if !found {
+		t.Error("metrics should keep recording to the previous provider")
+	}
+
+	if err := InitMetrics("webkit_test", WithMetricsPrometheusRegisterer(registry)); err != nil {
+		t.Fatal(err)
+	}
+	if err := reader.Collect(context.Background(), &rm); err == nil {
+		t.Error("previous provider should be shut down after retry")
+	}
+	RecordAlarmStatsMetric("svc", "fn")
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("gather: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() == "alarm_stats_service_function_total" {
+			if n := len(family.GetMetric()); n != 1 {
+				t.Errorf("series = %d, want 1", n)
+			}
+			return
+		}
+	}
+	t.Error("alarm_stats_service_function_total not gathered")
+}
@@ -0,0 +1,200 @@
+// Package metricstest 将 webkit 指标接入内存中的 OTel ManualReader，用于在单测中断言指标
+//
+//	func TestXxx(t *testing.T) {
+//		m := metricstest.Install(t)
+//		webkit.RecordAlarmStatsMetric("svc", "fn")
+//		m.AssertCounter(t, "alarm_stats_service_function_total", metricstest.Attrs{"function": "fn"}, 1)
+//	}
+//
+// 指标为包级全局状态，使用 Install 的测试不能并行执行
+package metricstest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/seanbit/kratos/webkit"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// Attrs 期望的属性，只要求数据点包含这些属性，未列出的属性不参与比较
+type Attrs map[string]string
+
+func (a Attrs) match(set attribute.Set) bool {
+	for k, v := range a {
+		got, ok := set.Value(attribute.Key(k))
+		if !ok || got.Emit() != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Reader 收集 webkit 指标的内存 Reader
+type Reader struct {
+	name   string
+	reader *sdkmetric.ManualReader
+}
+
+// Install 以 ManualReader 初始化 webkit 指标，测试结束时关闭并恢复为 no-op
+func Install(t testing.TB, opts ...webkit.MetricsOption) *Reader {
+	t.Helper()
+	r := &Reader{name: t.Name()}
+	r.install(t, opts...)
+	t.Cleanup(func() {
+		if err := webkit.ShutdownMetrics(context.Background()); err != nil {
+			t.Errorf("shutdown metrics: %v", err)
+		}
+	})
+	return r
+}
+
+func (r *Reader) install(t testing.TB, opts ...webkit.MetricsOption) {
+	t.Helper()
+	r.reader = sdkmetric.NewManualReader()
+	opts = append([]webkit.MetricsOption{
+		webkit.WithMetricsExporters(),
+		webkit.WithMetricsReader(r.reader),
+	}, opts...)
+	if err := webkit.InitMetrics(r.name, opts...); err != nil {
+		t.Fatalf("init metrics: %v", err)
+	}
+}
+
+// Reset 丢弃已记录的指标，重新安装空的 Reader
+func (r *Reader) Reset(t testing.TB, opts ...webkit.MetricsOption) {
+	t.Helper()
+	if err := webkit.ShutdownMetrics(context.Background()); err != nil {
+		t.Fatalf("shutdown metrics: %v", err)
+	}
+	r.install(t, opts...)
+}
+
+// Collect 收集当前所有指标
+func (r *Reader) Collect(t testing.TB) metricdata.ResourceMetrics {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := r.reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("collect metrics: %v", err)
+	}
+	return rm
+}
+
+// Metric 按名称查找指标，未记录过的指标返回 false
+func (r *Reader) Metric(t testing.TB, name string) (metricdata.Metrics, bool) {
+	t.Helper()
+	rm := r.Collect(t)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return m, true
+			}
+		}
+	}
+	return metricdata.Metrics{}, false
+}
+
+// CounterValue 计数器中包含 attrs 的数据点之和
+func (r *Reader) CounterValue(t testing.TB, name string, attrs Attrs) int64 {
+	t.Helper()
+	m, ok := r.Metric(t, name)
+	if !ok {
+		return 0
+	}
+	var total int64
+	switch data := m.Data.(type) {
+	case metricdata.Sum[int64]:
+		for _, dp := range data.DataPoints {
+			if attrs.match(dp.Attributes) {
+				total += dp.Value
+			}
+		}
+	case metricdata.Sum[float64]:
+		for _, dp := range data.DataPoints {
+			if attrs.match(dp.Attributes) {
+				total += int64(dp.Value)
+			}
+		}
+	default:
+		t.Fatalf("metric %s is %T, not a counter", name, m.Data)
+	}
+	return total
+}
+
+// AssertCounter 断言计数器中包含 attrs 的数据点之和等于 want
+func (r *Reader) AssertCounter(t testing.TB, name string, attrs Attrs, want int64) {
+	t.Helper()
+	if got := r.CounterValue(t, name, attrs); got != want {
+		t.Errorf("counter %s%v = %d, want %d", name, attrs, got, want)
+	}
+}
+
+// GaugeValue 仪表中包含 attrs 的数据点之和
+func (r *Reader) GaugeValue(t testing.TB, name string, attrs Attrs) float64 {
+	t.Helper()
+	m, ok := r.Metric(t, name)
+	if !ok {
+		return 0
+	}
+	var total float64
+	switch data := m.Data.(type) {
+	case metricdata.Gauge[int64]:
+		for _, dp := range data.DataPoints {
+			if attrs.match(dp.Attributes) {
+				total += float64(dp.Value)
+			}
+		}
+	case metricdata.Gauge[float64]:
+		for _, dp := range data.DataPoints {
+			if attrs.match(dp.Attributes) {
+				total += dp.Value
+			}
+		}
+	default:
+		t.Fatalf("metric %s is %T, not a gauge", name, m.Data)
+	}
+	return total
+}
+
+// HistogramCount 直方图中包含 attrs 的数据点的记录次数
+func (r *Reader) HistogramCount(t testing.TB, name string, attrs Attrs) uint64 {
+	t.Helper()
+	count, _ := r.histogram(t, name, attrs)
+	return count
+}
+
+// HistogramSum 直方图中包含 attrs 的数据点的记录值之和
+func (r *Reader) HistogramSum(t testing.TB, name string, attrs Attrs) float64 {
+	t.Helper()
+	_, sum := r.histogram(t, name, attrs)
+	return sum
+}
+
+func (r *Reader) histogram(t testing.TB, name string, attrs Attrs) (count uint64, sum float64) {
+	t.Helper()
+	m, ok := r.Metric(t, name)
+	if !ok {
+		return 0, 0
+	}
+	switch data := m.Data.(type) {
+	case metricdata.Histogram[float64]:
+		for _, dp := range data.DataPoints {
+			if attrs.match(dp.Attributes) {
+				count += dp.Count
+				sum += dp.Sum
+			}
+		}
+	case metricdata.Histogram[int64]:
+		for _, dp := range data.DataPoints {
+			if attrs.match(dp.Attributes) {
+				count += dp.Count
+				sum += float64(dp.Sum)
+			}
+		}
+	default:
+		t.Fatalf("metric %s is %T, not a histogram", name, m.Data)
+	}
+	return count, sum
+}
@@ -0,0 +1,169 @@
+package metricstest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/hibiken/asynq"
+	"github.com/redis/go-redis/v9"
+	"github.com/seanbit/kratos/webkit"
+)
+
+// httpTransport 实现 khttp.Transporter，用于在无 server 的情况下调用中间件
+type httpTransport struct {
+	req *http.Request
+}
+
+func (t *httpTransport) Kind() transport.Kind            { return transport.KindHTTP }
+func (t *httpTransport) Endpoint() string                { return "" }
+func (t *httpTransport) Operation() string               { return t.req.URL.Path }
+func (t *httpTransport) RequestHeader() transport.Header { return header(t.req.Header) }
+func (t *httpTransport) ReplyHeader() transport.Header   { return header(http.Header{}) }
+func (t *httpTransport) Request() *http.Request          { return t.req }
+func (t *httpTransport) PathTemplate() string            { return t.req.URL.Path }
+
+type header http.Header
+
+func (h header) Get(key string) string      { return http.Header(h).Get(key) }
+func (h header) Set(key, value string)      { http.Header(h).Set(key, value) }
+func (h header) Add(key, value string)      { http.Header(h).Add(key, value) }
+func (h header) Values(key string) []string { return http.Header(h).Values(key) }
+func (h header) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestInterceptorMetrics(t *testing.T) {
+	m := Install(t)
+	ti, err := webkit.NewTrafficInterceptor(webkit.WithInterceptServerName("svc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := ti.Middleware()(func(context.Context, interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/probe", nil)
+		ctx := transport.NewServerContext(context.Background(), &httpTransport{req: req})
+		if _, err := handler(ctx, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m.AssertCounter(t, "server_requests_intercept", Attrs{"server_name": "svc", "path": "/probe", "has_sign": "0"}, 2)
+	m.AssertCounter(t, "server_requests_intercept", Attrs{"block": "1"}, 0)
+}
+
+func TestAlarmMetrics(t *testing.T) {
+	m := Install(t)
+	webkit.RecordAlarmStatsMetric("svc", "login")
+	webkit.RecordPlatformMetric("error", "web", "title", "msg")
+	webkit.RecordPlatformMetric("error", "web", "title", "msg")
+	m.AssertCounter(t, "alarm_stats_service_function_total", Attrs{"function": "login"}, 1)
+	m.AssertCounter(t, "alarm_platform_api_total", Attrs{"level": "error", "platform": "web"}, 2)
+
+	webkit.RecordMethodDurationMetric("svc", "/login", "query", 0.2)
+	webkit.RecordMethodDurationMetric("svc", "/login", "query", 0.3)
+	if got := m.HistogramCount(t, "alarm_platform_method_cost", Attrs{"proc": "query"}); got != 2 {
+		t.Errorf("histogram count = %d, want 2", got)
+	}
+	if got := m.HistogramSum(t, "alarm_platform_method_cost", nil); got < 0.49 || got > 0.51 {
+		t.Errorf("histogram sum = %v, want 0.5", got)
+	}
+
+	m.Reset(t)
+	m.AssertCounter(t, "alarm_stats_service_function_total", nil, 0)
+}
+
+type poolStater struct{ stats redis.PoolStats }
+
+func (p *poolStater) PoolStats() *redis.PoolStats { return &p.stats }
+
+type queueInspector struct{ infos map[string]*asynq.QueueInfo }
+
+func (i *queueInspector) Queues() ([]string, error) {
+	queues := make([]string, 0, len(i.infos))
+	for q := range i.infos {
+		queues = append(queues, q)
+	}
+	return queues, nil
+}
+
+func (i *queueInspector) GetQueueInfo(queue string) (*asynq.QueueInfo, error) {
+	return i.infos[queue], nil
+}
+
+type nopConnector struct{}
+
+func (nopConnector) Connect(context.Context) (driver.Conn, error) { return nil, errors.New("nop") }
+func (nopConnector) Driver() driver.Driver                        { return nil }
+
+func TestPoolGauges(t *testing.T) {
+	m := Install(t)
+
+	db := sql.OpenDB(nopConnector{})
+	defer db.Close()
+	db.SetMaxOpenConns(5)
+	unregisterDB, err := webkit.RegisterDBStatsMetrics("master", db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cli := &poolStater{stats: redis.PoolStats{Hits: 7, Timeouts: 2, TotalConns: 10, IdleConns: 4}}
+	if _, err := webkit.RegisterRedisPoolMetrics("cache", cli); err != nil {
+		t.Fatal(err)
+	}
+	inspector := &queueInspector{infos: map[string]*asynq.QueueInfo{
+		"default": {Queue: "default", Size: 9, Pending: 6, Retry: 2, Archived: 1, Latency: 3 * time.Second, FailedTotal: 4},
+	}}
+	if _, err := webkit.RegisterAsynqQueueMetrics(inspector); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		attrs Attrs
+		want  float64
+	}{
+		{"db_pool_max_open_connections", Attrs{"pool": "master"}, 5},
+		{"db_pool_connections", Attrs{"pool": "master", "state": "in_use"}, 0},
+		{"redis_pool_connections", Attrs{"pool": "cache", "state": "idle"}, 4},
+		{"asynq_queue_size", Attrs{"queue": "default"}, 9},
+		{"asynq_queue_tasks", Attrs{"queue": "default", "state": "retry"}, 2},
+		{"asynq_queue_latency_seconds", Attrs{"queue": "default"}, 3},
+	}
+	for _, tt := range tests {
+		if got := m.GaugeValue(t, tt.name, tt.attrs); got != tt.want {
+			t.Errorf("%s%v = %v, want %v", tt.name, tt.attrs, got, tt.want)
+		}
+	}
+	m.AssertCounter(t, "redis_pool_requests_total", Attrs{"result": "timeout"}, 2)
+	m.AssertCounter(t, "asynq_queue_processed_total", Attrs{"queue": "default", "result": "failed"}, 4)
+
+	// 注销后不再采集
+	unregisterDB()
+	if _, ok := m.Metric(t, "db_pool_max_open_connections"); ok {
+		t.Error("db pool metrics still collected after unregister")
+	}
+}
+
+func TestRuntimeMetrics(t *testing.T) {
+	m := Install(t)
+	if err := webkit.RegisterRuntimeMetrics(); err != nil {
+		t.Fatal(err)
+	}
+	if m.GaugeValue(t, "process_memory_bytes", Attrs{"type": "rss"}) <= 0 {
+		t.Error("process rss not collected")
+	}
+	if _, ok := m.Metric(t, "go.goroutine.count"); !ok {
+		t.Error("go runtime metrics not collected")
+	}
+}
@@ -4,14 +4,35 @@ import (
 	"github.com/go-kratos/kratos/contrib/middleware/validate/v2"
 	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/middleware/metrics"
-	"github.com/go-kratos/kratos/v2/middleware/ratelimit"
 	"github.com/go-kratos/kratos/v2/middleware/recovery"
 	"github.com/go-kratos/kratos/v2/middleware/tracing"
 	sentrykratos "github.com/go-kratos/sentry"
 )
 
-func PrepareMiddleWare() []middleware.Middleware {
-	return []middleware.Middleware{
+type middlewareOptions struct {
+	loadShedder *LoadShedder
+}
+
+// MiddlewareOption PrepareMiddleWare 选项
+type MiddlewareOption func(*middlewareOptions)
+
+// WithMiddlewareLoadShedder 启用过载保护，未设置时不包含过载保护中间件
+func WithMiddlewareLoadShedder(ls *LoadShedder) MiddlewareOption {
+	return func(o *middlewareOptions) {
+		o.loadShedder = ls
+	}
+}
+
+// PrepareMiddleWare HTTP 与 gRPC 共用的中间件，过载保护仅在 WithMiddlewareLoadShedder 时启用，位于 recovery 之后
+//
+// 流量拦截依赖登录用户（uid 字段、粘性采样、rate_limit 的 uid 维度），不包含在内，
+// 需在鉴权中间件之后追加 TrafficInterceptor.Middleware
+func PrepareMiddleWare(opts ...MiddlewareOption) []middleware.Middleware {
+	o := &middlewareOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	middlewares := []middleware.Middleware{
 		metrics.Server(
 			metrics.WithSeconds(_metricSeconds),
 			metrics.WithRequests(_metricRequests),
@@ -19,9 +40,26 @@ func PrepareMiddleWare() []middleware.Middleware {
 		tracing.Server(),
 		WriteResponseHeaderTraceId(),
 		ServerLogging(),
-		validate.ProtoValidate(),
-		ratelimit.Server(),
 		recovery.Recovery(),
 		sentrykratos.Server(), // must after Recovery middleware, because of the exiting order will be reversed
 	}
+	if o.loadShedder != nil {
+		// 过载保护先于鉴权与流量拦截，过载时不再执行签名校验、Redis 读写等请求级开销
+		middlewares = append(middlewares, o.loadShedder.Middleware())
+	}
+	return append(middlewares, validate.ProtoValidate())
+}
+
+// TaskMiddleWare 后台任务（如 asynq）的中间件，不含流量拦截、参数校验与负载保护
+func TaskMiddleWare() []middleware.Middleware {
+	return []middleware.Middleware{
+		metrics.Server(
+			metrics.WithSeconds(_metricSeconds),
+			metrics.WithRequests(_metricRequests),
+		),
+		tracing.Server(),
+		ServerLogging(),
+		recovery.Recovery(),
+		sentrykratos.Server(),
+	}
 }
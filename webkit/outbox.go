@@ -0,0 +1,221 @@
+package webkit
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+	webasynq "github.com/seanbit/kratos/webkit/transport/asynq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultOutboxTable 发件箱默认表名，建表语句见 OutboxMessage
+const DefaultOutboxTable = "outbox_message"
+
+// OutboxMessage 发件箱中的一条事件，与业务数据在同一事务中写入，由 OutboxRelay 投递
+//
+//	CREATE TABLE outbox_message (
+//	    id           CHARACTER VARYING(64)  PRIMARY KEY,
+//	    topic        CHARACTER VARYING(256) NOT NULL,
+//	    payload      BYTEA                  NOT NULL,
+//	    headers      TEXT                   NOT NULL DEFAULT '',
+//	    attempts     INTEGER                NOT NULL DEFAULT 0,
+//	    last_error   TEXT                   NOT NULL DEFAULT '',
+//	    created_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+//	    available_at TIMESTAMP WITH TIME ZONE NOT NULL,
+//	    published_at TIMESTAMP WITH TIME ZONE
+//	);
+//	CREATE INDEX idx_outbox_message_pending ON outbox_message (available_at) WHERE published_at IS NULL;
+type OutboxMessage struct {
+	// ID 去重 ID，投递到 asynq 时作为 TaskID
+	ID string `gorm:"column:id;primaryKey"`
+	// Topic 事件类型，即 proto 消息全名
+	Topic   string `gorm:"column:topic"`
+	Payload []byte `gorm:"column:payload"`
+	// Headers 写入时的 trace context 与 baggage（JSON），投递时还原
+	Headers     string     `gorm:"column:headers"`
+	Attempts    int32      `gorm:"column:attempts"`
+	LastError   string     `gorm:"column:last_error"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	AvailableAt time.Time  `gorm:"column:available_at"`
+	PublishedAt *time.Time `gorm:"column:published_at"`
+}
+
+// Outbox 在业务事务中写入事件
+//
+//	err := db.Transaction(func(tx *gorm.DB) error {
+//		if err := tx.Create(user).Error; err != nil {
+//			return err
+//		}
+//		_, err := outbox.Add(ctx, tx, &event.UserLogin{...})
+//		return err
+//	})
+type Outbox struct {
+	table string
+}
+
+// NewOutbox 创建发件箱，table 为空时使用 DefaultOutboxTable
+func NewOutbox(table string) *Outbox {
+	if table == "" {
+		table = DefaultOutboxTable
+	}
+	return &Outbox{table: table}
+}
+
+// Add 在 tx 中写入事件，事务提交后才会被投递，回滚时一并撤销
+func (o *Outbox) Add(ctx context.Context, tx *gorm.DB, msg proto.Message) (*OutboxMessage, error) {
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	headers, err := json.Marshal(carrier)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	now := time.Now()
+	record := &OutboxMessage{
+		ID:          uuid.NewString(),
+		Topic:       string(proto.MessageName(msg)),
+		Payload:     payload,
+		Headers:     string(headers),
+		CreatedAt:   now,
+		AvailableAt: now,
+	}
+	if err := tx.WithContext(ctx).Table(o.table).Create(record).Error; err != nil {
+		return nil, errors.Wrap(err, "outbox: add")
+	}
+	return record, nil
+}
+
+// context 还原写入时的 trace context 与 baggage
+func (m *OutboxMessage) context(ctx context.Context) context.Context {
+	if m.Headers == "" {
+		return ctx
+	}
+	carrier := propagation.MapCarrier{}
+	if err := json.Unmarshal([]byte(m.Headers), &carrier); err != nil {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
+
+// OutboxStore 发件箱存储，多个 OutboxRelay 实例可共享同一存储
+type OutboxStore interface {
+	// Claim 领取最多 limit 条到期未发布的消息，领取的消息在 lease 内不会被其他实例领取
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]*OutboxMessage, error)
+	// MarkPublished 标记消息已发布
+	MarkPublished(ctx context.Context, ids []string) error
+	// MarkFailed 记录发布失败原因，消息在 retryAt 后重新可领取
+	MarkFailed(ctx context.Context, id, reason string, retryAt time.Time) error
+	// Backlog 未发布消息数与其中最早的写入时间
+	Backlog(ctx context.Context) (count int64, oldest time.Time, err error)
+	// Purge 删除 before 之前已发布的消息
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+type gormOutboxStore struct {
+	db    *gorm.DB
+	table string
+}
+
+// NewGormOutboxStore 基于 Postgres 的发件箱存储，使用 FOR UPDATE SKIP LOCKED 领取消息
+func NewGormOutboxStore(db *gorm.DB, table string) OutboxStore {
+	if table == "" {
+		table = DefaultOutboxTable
+	}
+	return &gormOutboxStore{db: db, table: table}
+}
+
+func (s *gormOutboxStore) Claim(ctx context.Context, limit int, lease time.Duration) ([]*OutboxMessage, error) {
+	now := time.Now()
+	var msgs []*OutboxMessage
+	err := s.db.WithContext(ctx).Raw(`UPDATE ? SET available_at = ?, attempts = attempts + 1
+WHERE id IN (
+	SELECT id FROM ? WHERE published_at IS NULL AND available_at <= ?
+	ORDER BY available_at LIMIT ? FOR UPDATE SKIP LOCKED
+) RETURNING *`,
+		clause.Table{Name: s.table}, now.Add(lease), clause.Table{Name: s.table}, now, limit,
+	).Scan(&msgs).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "outbox: claim")
+	}
+	return msgs, nil
+}
+
+func (s *gormOutboxStore) MarkPublished(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	err := s.db.WithContext(ctx).Table(s.table).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{"published_at": time.Now(), "last_error": ""}).Error
+	return errors.Wrap(err, "outbox: mark published")
+}
+
+func (s *gormOutboxStore) MarkFailed(ctx context.Context, id, reason string, retryAt time.Time) error {
+	err := s.db.WithContext(ctx).Table(s.table).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"available_at": retryAt, "last_error": reason}).Error
+	return errors.Wrap(err, "outbox: mark failed")
+}
+
+func (s *gormOutboxStore) Backlog(ctx context.Context) (int64, time.Time, error) {
+	var row struct {
+		Count  int64
+		Oldest *time.Time
+	}
+	err := s.db.WithContext(ctx).Table(s.table).
+		Select("COUNT(*) AS count, MIN(created_at) AS oldest").
+		Where("published_at IS NULL").
+		Scan(&row).Error
+	if err != nil {
+		return 0, time.Time{}, errors.Wrap(err, "outbox: backlog")
+	}
+	if row.Oldest == nil {
+		return row.Count, time.Time{}, nil
+	}
+	return row.Count, *row.Oldest, nil
+}
+
+func (s *gormOutboxStore) Purge(ctx context.Context, before time.Time) (int64, error) {
+	result := s.db.WithContext(ctx).Table(s.table).
+		Where("published_at IS NOT NULL AND published_at < ?", before).
+		Delete(&OutboxMessage{})
+	if result.Error != nil {
+		return 0, errors.Wrap(result.Error, "outbox: purge")
+	}
+	return result.RowsAffected, nil
+}
+
+// OutboxPublisher 将发件箱消息投递到消息队列，重复投递同一 ID 的消息应视为成功
+type OutboxPublisher interface {
+	PublishOutbox(ctx context.Context, msg *OutboxMessage) error
+}
+
+// OutboxPublisherFunc 函数形式的 OutboxPublisher
+type OutboxPublisherFunc func(ctx context.Context, msg *OutboxMessage) error
+
+func (f OutboxPublisherFunc) PublishOutbox(ctx context.Context, msg *OutboxMessage) error {
+	return f(ctx, msg)
+}
+
+// NewAsynqOutboxPublisher 以 Topic 为任务类型、ID 为 TaskID 投递到 asynq，TaskID 冲突视为已投递
+func NewAsynqOutboxPublisher(client *webasynq.Client, opts ...asynq.Option) OutboxPublisher {
+	return OutboxPublisherFunc(func(ctx context.Context, msg *OutboxMessage) error {
+		taskOpts := append([]asynq.Option{asynq.TaskID(msg.ID)}, opts...)
+		_, err := client.Enqueue(ctx, msg.Topic, msg.Payload, taskOpts...)
+		if errors.Is(err, asynq.ErrTaskIDConflict) {
+			return nil
+		}
+		return err
+	})
+}
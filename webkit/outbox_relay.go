@@ -0,0 +1,259 @@
+package webkit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	defaultOutboxInterval   = time.Second
+	defaultOutboxBatchSize  = 100
+	defaultOutboxLease      = 30 * time.Second
+	defaultOutboxMaxBackoff = 5 * time.Minute
+	defaultOutboxRetention  = 7 * 24 * time.Hour
+)
+
+var _ transport.Server = (*OutboxRelay)(nil)
+
+// OutboxRelay 轮询发件箱并投递到消息队列的后台服务，至少投递一次
+//
+// 投递成功但标记失败时消息会被再次投递，消费端应以消息 ID 去重
+type OutboxRelay struct {
+	store     OutboxStore
+	publisher OutboxPublisher
+
+	interval   time.Duration
+	batchSize  int
+	lease      time.Duration
+	maxBackoff time.Duration
+	retention  time.Duration
+	logger     *log.Helper
+
+	backlog       atomic.Int64
+	oldestPending atomic.Int64 // unix nano，0 表示无积压
+
+	cancel     context.CancelFunc
+	done       chan struct{}
+	unregister func()
+	mu         sync.Mutex
+}
+
+// OutboxRelayOption OutboxRelay 选项
+type OutboxRelayOption func(*OutboxRelay)
+
+// WithOutboxInterval 轮询间隔，默认1秒；一轮领取满一批时立即继续
+func WithOutboxInterval(interval time.Duration) OutboxRelayOption {
+	return func(r *OutboxRelay) {
+		r.interval = interval
+	}
+}
+
+// WithOutboxBatchSize 每轮领取的消息数，默认100
+func WithOutboxBatchSize(size int) OutboxRelayOption {
+	return func(r *OutboxRelay) {
+		r.batchSize = size
+	}
+}
+
+// WithOutboxLease 领取后的租期，租期内未确认的消息会被其他实例重新领取，默认30秒
+func WithOutboxLease(lease time.Duration) OutboxRelayOption {
+	return func(r *OutboxRelay) {
+		r.lease = lease
+	}
+}
+
+// WithOutboxMaxBackoff 投递失败后重试间隔的上限，默认5分钟
+func WithOutboxMaxBackoff(backoff time.Duration) OutboxRelayOption {
+	return func(r *OutboxRelay) {
+		r.maxBackoff = backoff
+	}
+}
+
+// WithOutboxRetention 已发布消息的保留时间，默认7天，0 表示不清理
+func WithOutboxRetention(retention time.Duration) OutboxRelayOption {
+	return func(r *OutboxRelay) {
+		r.retention = retention
+	}
+}
+
+// WithOutboxLogger 设置日志
+func WithOutboxLogger(logger log.Logger) OutboxRelayOption {
+	return func(r *OutboxRelay) {
+		r.logger = log.NewHelper(logger)
+	}
+}
+
+// NewOutboxRelay 创建投递服务，作为 kratos.Server 随应用启停
+func NewOutboxRelay(store OutboxStore, publisher OutboxPublisher, opts ...OutboxRelayOption) *OutboxRelay {
+	r := &OutboxRelay{
+		store:      store,
+		publisher:  publisher,
+		interval:   defaultOutboxInterval,
+		batchSize:  defaultOutboxBatchSize,
+		lease:      defaultOutboxLease,
+		maxBackoff: defaultOutboxMaxBackoff,
+		retention:  defaultOutboxRetention,
+		logger:     log.NewHelper(log.GetLogger()),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Start 注册积压指标并启动轮询
+func (r *OutboxRelay) Start(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cancel != nil {
+		return nil
+	}
+	unregister, err := r.registerMetrics()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel, r.done, r.unregister = cancel, make(chan struct{}), unregister
+	go r.run(ctx)
+	r.logger.Info("outbox relay started")
+	return nil
+}
+
+// Stop 停止轮询，等待进行中的一轮投递结束
+func (r *OutboxRelay) Stop(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cancel == nil {
+		return nil
+	}
+	r.cancel()
+	select {
+	case <-r.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	r.unregister()
+	r.cancel = nil
+	r.logger.Info("outbox relay stopped")
+	return nil
+}
+
+func (r *OutboxRelay) run(ctx context.Context) {
+	defer close(r.done)
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	var lastPurge time.Time
+	for {
+		n, err := r.relay(ctx)
+		if err != nil && ctx.Err() == nil {
+			r.logger.Errorf("outbox relay: %+v", err)
+		}
+		if r.retention > 0 && time.Since(lastPurge) > time.Hour {
+			lastPurge = time.Now()
+			if _, err := r.store.Purge(ctx, lastPurge.Add(-r.retention)); err != nil && ctx.Err() == nil {
+				r.logger.Errorf("outbox purge: %+v", err)
+			}
+		}
+		// 领取满一批说明仍有积压，立即继续
+		if n >= r.batchSize {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// relay 领取并投递一批消息，返回领取数
+func (r *OutboxRelay) relay(ctx context.Context) (int, error) {
+	defer r.refreshBacklog(ctx)
+
+	msgs, err := r.store.Claim(ctx, r.batchSize, r.lease)
+	if err != nil {
+		return 0, err
+	}
+	published := make([]string, 0, len(msgs))
+	for _, msg := range msgs {
+		if err := r.publisher.PublishOutbox(msg.context(ctx), msg); err != nil {
+			RecordMetricOutboxPublishWithCtx(ctx, msg.Topic, "error")
+			retryAt := time.Now().Add(r.backoff(msg.Attempts))
+			if markErr := r.store.MarkFailed(ctx, msg.ID, err.Error(), retryAt); markErr != nil {
+				r.logger.Errorf("outbox mark failed %s: %+v", msg.ID, markErr)
+			}
+			continue
+		}
+		RecordMetricOutboxPublishWithCtx(ctx, msg.Topic, "ok")
+		published = append(published, msg.ID)
+	}
+	if err := r.store.MarkPublished(ctx, published); err != nil {
+		return len(msgs), errors.WithMessagef(err, "%d messages will be published again", len(published))
+	}
+	return len(msgs), nil
+}
+
+// backoff 第 attempts 次投递失败后的重试间隔：1s、2s、4s…，不超过 maxBackoff
+func (r *OutboxRelay) backoff(attempts int32) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	d := time.Duration(math.Pow(2, float64(attempts-1))) * time.Second
+	if d <= 0 || d > r.maxBackoff {
+		return r.maxBackoff
+	}
+	return d
+}
+
+func (r *OutboxRelay) refreshBacklog(ctx context.Context) {
+	count, oldest, err := r.store.Backlog(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			r.logger.Warnf("outbox backlog: %+v", err)
+		}
+		return
+	}
+	r.backlog.Store(count)
+	if count == 0 || oldest.IsZero() {
+		r.oldestPending.Store(0)
+	} else {
+		r.oldestPending.Store(oldest.UnixNano())
+	}
+}
+
+// registerMetrics outbox_backlog_messages 未发布消息数，outbox_backlog_age_seconds 最早未发布消息的等待时间
+func (r *OutboxRelay) registerMetrics() (func(), error) {
+	count, err := meter.Int64ObservableGauge("outbox_backlog_messages",
+		metric.WithDescription("发件箱中未发布的消息数"),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	age, err := meter.Float64ObservableGauge("outbox_backlog_age_seconds",
+		metric.WithDescription("发件箱中最早未发布消息的等待时间"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return registerCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveInt64(count, r.backlog.Load())
+		var seconds float64
+		if oldest := r.oldestPending.Load(); oldest > 0 {
+			seconds = time.Since(time.Unix(0, oldest)).Seconds()
+		}
+		o.ObserveFloat64(age, seconds)
+		return nil
+	}, count, age)
+}
@@ -0,0 +1,148 @@
+package webkit
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryOutboxStore 单测使用的内存发件箱
+type memoryOutboxStore struct {
+	mu   sync.Mutex
+	msgs map[string]*OutboxMessage
+}
+
+func newMemoryOutboxStore(msgs ...*OutboxMessage) *memoryOutboxStore {
+	s := &memoryOutboxStore{msgs: make(map[string]*OutboxMessage)}
+	for _, m := range msgs {
+		m.AvailableAt = m.CreatedAt
+		s.msgs[m.ID] = m
+	}
+	return s
+}
+
+func (s *memoryOutboxStore) Claim(_ context.Context, limit int, lease time.Duration) ([]*OutboxMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	var claimed []*OutboxMessage
+	for _, m := range s.msgs {
+		if m.PublishedAt == nil && !m.AvailableAt.After(now) {
+			claimed = append(claimed, m)
+		}
+	}
+	sort.Slice(claimed, func(i, j int) bool { return claimed[i].ID < claimed[j].ID })
+	if len(claimed) > limit {
+		claimed = claimed[:limit]
+	}
+	for _, m := range claimed {
+		m.AvailableAt = now.Add(lease)
+		m.Attempts++
+	}
+	return claimed, nil
+}
+
+func (s *memoryOutboxStore) MarkPublished(_ context.Context, ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for _, id := range ids {
+		s.msgs[id].PublishedAt = &now
+	}
+	return nil
+}
+
+func (s *memoryOutboxStore) MarkFailed(_ context.Context, id, reason string, retryAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.msgs[id].LastError = reason
+	s.msgs[id].AvailableAt = retryAt
+	return nil
+}
+
+func (s *memoryOutboxStore) Backlog(context.Context) (int64, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var count int64
+	var oldest time.Time
+	for _, m := range s.msgs {
+		if m.PublishedAt != nil {
+			continue
+		}
+		count++
+		if oldest.IsZero() || m.CreatedAt.Before(oldest) {
+			oldest = m.CreatedAt
+		}
+	}
+	return count, oldest, nil
+}
+
+func (s *memoryOutboxStore) Purge(context.Context, time.Time) (int64, error) { return 0, nil }
+
+func TestOutboxRelay(t *testing.T) {
+	created := time.Now().Add(-time.Minute)
+	store := newMemoryOutboxStore(
+		&OutboxMessage{ID: "1", Topic: "user.login", CreatedAt: created},
+		&OutboxMessage{ID: "2", Topic: "user.logout", CreatedAt: created.Add(time.Second)},
+		&OutboxMessage{ID: "3", Topic: "user.login", CreatedAt: created.Add(2 * time.Second)},
+	)
+	var published []string
+	relay := NewOutboxRelay(store, OutboxPublisherFunc(func(ctx context.Context, msg *OutboxMessage) error {
+		if msg.Topic == "user.logout" {
+			return errors.New("redis down")
+		}
+		published = append(published, msg.ID)
+		return nil
+	}), WithOutboxBatchSize(2))
+
+	tests := []struct {
+		claimed   int
+		published []string
+		backlog   int64
+	}{
+		{2, []string{"1"}, 2},      // 2 投递失败，等待退避
+		{1, []string{"1", "3"}, 1}, // 3 在下一批
+		{0, []string{"1", "3"}, 1},
+	}
+	for i, tt := range tests {
+		n, err := relay.relay(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != tt.claimed || len(published) != len(tt.published) || relay.backlog.Load() != tt.backlog {
+			t.Errorf("round %d: claimed %d, published %v, backlog %d", i, n, published, relay.backlog.Load())
+		}
+	}
+	failed := store.msgs["2"]
+	if failed.LastError != "redis down" || failed.Attempts != 1 || !failed.AvailableAt.After(time.Now()) {
+		t.Errorf("failed message = %+v", failed)
+	}
+	if oldest := relay.oldestPending.Load(); oldest != failed.CreatedAt.UnixNano() {
+		t.Errorf("oldest pending = %v, want %v", time.Unix(0, oldest), failed.CreatedAt)
+	}
+
+	if err := relay.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := relay.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	relay := NewOutboxRelay(nil, nil, WithOutboxMaxBackoff(time.Minute))
+	for attempts, want := range map[int32]time.Duration{
+		0:   time.Second,
+		1:   time.Second,
+		3:   4 * time.Second,
+		7:   time.Minute,
+		100: time.Minute,
+	} {
+		if got := relay.backoff(attempts); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
@@ -0,0 +1,445 @@
+package webkit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// RateLimitKeyPrefix 限流计数的 Redis key：服务名、规则名、限流维度
+	RateLimitKeyPrefix = "rate_limit_%s:%s:%s"
+
+	// RateLimitReason 限流错误的 reason，与 kratos ratelimit 中间件一致，ErrorEncoder 映射为 429
+	RateLimitReason = "RATELIMIT"
+)
+
+// 限流维度
+const (
+	RateLimitKeyUser      = "user"
+	RateLimitKeyIP        = "ip"
+	RateLimitKeyOperation = "operation"
+	// RateLimitKeyHeaderPrefix header:{name} 按请求头限流，例如 header:X-App-Id
+	RateLimitKeyHeaderPrefix = "header:"
+)
+
+// 限流响应头
+const (
+	RateLimitHeaderLimit     = "X-RateLimit-Limit"
+	RateLimitHeaderRemaining = "X-RateLimit-Remaining"
+	RateLimitHeaderReset     = "X-RateLimit-Reset"
+	RateLimitHeaderRetry     = "Retry-After"
+)
+
+var ErrRateLimited = kerrors.New(429, RateLimitReason, "too many requests")
+
+// RateLimitConfig 限流配置，请求需同时满足所有命中规则的配额
+type RateLimitConfig struct {
+	Rules []RateLimitRule `json:"rules"`
+	// FailClosed 限流存储不可用时拒绝请求，默认放行
+	FailClosed bool `json:"fail_closed,omitempty"`
+}
+
+// RateLimitRule 单条限流规则，每个限流 key 在 Period 秒内平均放行 Rate 次，允许 Burst 次突发
+type RateLimitRule struct {
+	// Name 规则名，作为 Redis key 的一部分，修改后计数重新开始；为空时为 rule_{下标}
+	Name string `json:"name,omitempty"`
+	// Operations 生效的 operation，以 * 结尾表示前缀匹配，为空或 * 匹配全部
+	Operations []string `json:"operations,omitempty"`
+	// Keys 限流维度，取值 user、ip、operation、header:{name}，默认 user，未登录时回退为 ip
+	Keys []string `json:"keys,omitempty"`
+	Rate int      `json:"rate"`
+	// Period 配额周期（秒），默认1
+	Period int `json:"period,omitempty"`
+	// Burst 突发容量，默认等于 Rate
+	Burst int `json:"burst,omitempty"`
+}
+
+// RateLimit 单个 key 的配额
+type RateLimit struct {
+	Rate   int
+	Period time.Duration
+	Burst  int
+}
+
+// RateLimitResult 一次限流判定的结果
+type RateLimitResult struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter 被拒绝时距离下次可放行的时间
+	RetryAfter time.Duration
+	// ResetAfter 配额完全恢复所需的时间
+	ResetAfter time.Duration
+}
+
+// RateLimitStore 限流计数存储，Allow 需保证同一 key 的判定与计数是原子的
+type RateLimitStore interface {
+	Allow(ctx context.Context, key string, limit RateLimit) (*RateLimitResult, error)
+}
+
+// ---------------- rate limiter ----------------
+
+// RateLimiter 基于 GCRA 的分布式限流器
+type RateLimiter struct {
+	serverName string
+	store      RateLimitStore
+	failClosed atomic.Bool
+	rules      atomic.Pointer[[]*compiledRateLimitRule]
+}
+
+// RateLimiterOption 限流器选项
+type RateLimiterOption func(*RateLimiter)
+
+// WithRateLimitServerName 服务名，区分共用 Redis 的不同服务
+func WithRateLimitServerName(name string) RateLimiterOption {
+	return func(l *RateLimiter) {
+		l.serverName = name
+	}
+}
+
+// NewRateLimiter 创建限流器，配置非法时返回错误
+func NewRateLimiter(store RateLimitStore, conf *RateLimitConfig, opts ...RateLimiterOption) (*RateLimiter, error) {
+	if store == nil {
+		return nil, errors.New("rate limit store is nil")
+	}
+	l := &RateLimiter{store: store}
+	for _, opt := range opts {
+		opt(l)
+	}
+	if err := l.Update(conf); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Update 热更新限流规则，非法配置不生效
+func (l *RateLimiter) Update(conf *RateLimitConfig) error {
+	if conf == nil {
+		conf = &RateLimitConfig{}
+	}
+	rules := make([]*compiledRateLimitRule, 0, len(conf.Rules))
+	for i, rule := range conf.Rules {
+		compiled, err := compileRateLimitRule(i, rule)
+		if err != nil {
+			return errors.Wrapf(err, "rate_limit rules[%d]", i)
+		}
+		rules = append(rules, compiled)
+	}
+	l.rules.Store(&rules)
+	l.failClosed.Store(conf.FailClosed)
+	return nil
+}
+
+// Middleware 限流中间件，需放在鉴权中间件之后才能按用户限流
+func (l *RateLimiter) Middleware() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if err := l.check(ctx); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+func (l *RateLimiter) check(ctx context.Context) error {
+	operation := GetOperationFromContext(ctx)
+	// 多条规则命中时，响应头取剩余配额最少的一条
+	var strictest *RateLimitResult
+	for _, rule := range *l.rules.Load() {
+		if !rule.matchOperation(operation) {
+			continue
+		}
+		key := rule.key(ctx, l.serverName, operation)
+		result, err := l.store.Allow(ctx, key, rule.limit)
+		if err != nil {
+			log.Context(ctx).Warnw(
+				"msg", "rate limit failed",
+				"rule", rule.name,
+				"err", err,
+			)
+			if l.failClosed.Load() {
+				return ErrRateLimited
+			}
+			continue
+		}
+		if strictest == nil || !result.Allowed || result.Remaining < strictest.Remaining {
+			strictest = result
+		}
+		if !result.Allowed {
+			break
+		}
+	}
+	if strictest == nil {
+		return nil
+	}
+	setRateLimitHeaders(ctx, strictest)
+	if !strictest.Allowed {
+		return ErrRateLimited
+	}
+	return nil
+}
+
+func setRateLimitHeaders(ctx context.Context, result *RateLimitResult) {
+	tr, ok := transport.FromServerContext(ctx)
+	if !ok {
+		return
+	}
+	header := tr.ReplyHeader()
+	header.Set(RateLimitHeaderLimit, strconv.Itoa(result.Limit))
+	header.Set(RateLimitHeaderRemaining, strconv.Itoa(result.Remaining))
+	header.Set(RateLimitHeaderReset, strconv.FormatInt(ceilSeconds(result.ResetAfter), 10))
+	if !result.Allowed {
+		header.Set(RateLimitHeaderRetry, strconv.FormatInt(ceilSeconds(result.RetryAfter), 10))
+	}
+}
+
+func ceilSeconds(d time.Duration) int64 {
+	if d <= 0 {
+		return 0
+	}
+	return int64(math.Ceil(d.Seconds()))
+}
+
+// compiledRateLimitRule 编译后的限流规则
+type compiledRateLimitRule struct {
+	name     string
+	exact    map[string]struct{}
+	prefixes []string
+	all      bool
+	keys     []string
+	limit    RateLimit
+}
+
+func compileRateLimitRule(i int, rule RateLimitRule) (*compiledRateLimitRule, error) {
+	if rule.Rate <= 0 {
+		return nil, errors.New("rate must be positive")
+	}
+	if rule.Period < 0 || rule.Burst < 0 {
+		return nil, errors.New("period and burst must not be negative")
+	}
+	compiled := &compiledRateLimitRule{
+		name:  rule.Name,
+		exact: make(map[string]struct{}),
+		keys:  rule.Keys,
+		limit: RateLimit{
+			Rate:   rule.Rate,
+			Period: time.Duration(rule.Period) * time.Second,
+			Burst:  rule.Burst,
+		},
+	}
+	if compiled.name == "" {
+		compiled.name = defaultRuleName(i)
+	}
+	if compiled.limit.Period == 0 {
+		compiled.limit.Period = time.Second
+	}
+	if compiled.limit.Burst == 0 {
+		compiled.limit.Burst = rule.Rate
+	}
+	if len(compiled.keys) == 0 {
+		compiled.keys = []string{RateLimitKeyUser}
+	}
+	for _, key := range compiled.keys {
+		switch {
+		case key == RateLimitKeyUser, key == RateLimitKeyIP, key == RateLimitKeyOperation:
+		case strings.HasPrefix(key, RateLimitKeyHeaderPrefix) && len(key) > len(RateLimitKeyHeaderPrefix):
+		default:
+			return nil, errors.Errorf("unsupported rate limit key: %q", key)
+		}
+	}
+	if len(rule.Operations) == 0 {
+		compiled.all = true
+	}
+	for _, op := range rule.Operations {
+		switch {
+		case op == "*":
+			compiled.all = true
+		case strings.HasSuffix(op, "*"):
+			compiled.prefixes = append(compiled.prefixes, strings.TrimSuffix(op, "*"))
+		default:
+			compiled.exact[op] = struct{}{}
+		}
+	}
+	return compiled, nil
+}
+
+func (r *compiledRateLimitRule) matchOperation(operation string) bool {
+	if r.all {
+		return true
+	}
+	if _, ok := r.exact[operation]; ok {
+		return true
+	}
+	for _, prefix := range r.prefixes {
+		if strings.HasPrefix(operation, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// key 按限流维度拼接 key，各维度带前缀避免 uid 与 ip 等取值冲突
+func (r *compiledRateLimitRule) key(ctx context.Context, serverName, operation string) string {
+	parts := make([]string, 0, len(r.keys))
+	for _, key := range r.keys {
+		switch key {
+		case RateLimitKeyUser:
+			if uid, ok := UserIdFromContext(ctx); ok {
+				parts = append(parts, "u="+uid)
+			} else {
+				parts = append(parts, "ip="+requestRealIP(ctx))
+			}
+		case RateLimitKeyIP:
+			parts = append(parts, "ip="+requestRealIP(ctx))
+		case RateLimitKeyOperation:
+			parts = append(parts, "op="+operation)
+		default:
+			name := strings.TrimPrefix(key, RateLimitKeyHeaderPrefix)
+			parts = append(parts, "h="+GetHeader(ctx, name))
+		}
+	}
+	return fmt.Sprintf(RateLimitKeyPrefix, serverName, r.name, strings.Join(parts, ","))
+}
+
+// ---------------- redis store ----------------
+
+// rateLimitScript GCRA 限流，TAT（理论到达时间）保存在 key 中，时间取 Redis 服务端时间避免实例间时钟偏差
+// 返回 {是否放行, 剩余次数, retry_after 秒, reset_after 秒}
+var rateLimitScript = redis.NewScript(`
+local key = KEYS[1]
+local burst = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local period = tonumber(ARGV[3])
+
+local emission_interval = period / rate
+local burst_offset = emission_interval * burst
+
+-- 以 2017-01-01 为纪元，避免秒与微秒相加时丢失浮点精度
+local now = redis.call("TIME")
+now = (now[1] - 1483228800) + (now[2] / 1000000)
+
+local tat = tonumber(redis.call("GET", key))
+if not tat or tat < now then
+  tat = now
+end
+
+local new_tat = tat + emission_interval
+local diff = now - (new_tat - burst_offset)
+if diff < 0 then
+  return {0, 0, tostring(-diff), tostring(tat - now)}
+end
+
+local reset_after = new_tat - now
+redis.call("SET", key, tostring(new_tat), "PX", math.ceil(reset_after * 1000))
+return {1, math.floor(diff / emission_interval), "0", tostring(reset_after)}
+`)
+
+type redisRateLimitStore struct {
+	cli redis.UniversalClient
+}
+
+// NewRedisRateLimitStore 基于 Redis Lua 脚本的限流存储，多副本共享配额
+func NewRedisRateLimitStore(cli redis.UniversalClient) RateLimitStore {
+	return &redisRateLimitStore{cli: cli}
+}
+
+func (s *redisRateLimitStore) Allow(ctx context.Context, key string, limit RateLimit) (*RateLimitResult, error) {
+	values, err := rateLimitScript.Run(ctx, s.cli, []string{key},
+		limit.Burst, limit.Rate, limit.Period.Seconds()).Slice()
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != 4 {
+		return nil, errors.Errorf("unexpected rate limit script result: %v", values)
+	}
+	allowed, _ := values[0].(int64)
+	remaining, _ := values[1].(int64)
+	retryAfter, err := parseScriptSeconds(values[2])
+	if err != nil {
+		return nil, err
+	}
+	resetAfter, err := parseScriptSeconds(values[3])
+	if err != nil {
+		return nil, err
+	}
+	return &RateLimitResult{
+		Allowed:    allowed == 1,
+		Limit:      limit.Burst,
+		Remaining:  int(remaining),
+		RetryAfter: retryAfter,
+		ResetAfter: resetAfter,
+	}, nil
+}
+
+func parseScriptSeconds(v interface{}) (time.Duration, error) {
+	s, _ := v.(string)
+	seconds, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "parse rate limit script result %v", v)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// ---------------- memory store ----------------
+
+// MemoryRateLimitStore 单机内存限流存储，与 Redis 脚本算法一致，用于测试与本地调试
+type MemoryRateLimitStore struct {
+	mu  sync.Mutex
+	now func() time.Time
+	tat map[string]time.Time
+}
+
+// NewMemoryRateLimitStore clock 为 nil 时使用 time.Now
+func NewMemoryRateLimitStore(clock func() time.Time) *MemoryRateLimitStore {
+	if clock == nil {
+		clock = time.Now
+	}
+	return &MemoryRateLimitStore{
+		now: clock,
+		tat: make(map[string]time.Time),
+	}
+}
+
+func (s *MemoryRateLimitStore) Allow(_ context.Context, key string, limit RateLimit) (*RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	emissionInterval := limit.Period / time.Duration(limit.Rate)
+	burstOffset := emissionInterval * time.Duration(limit.Burst)
+
+	tat, ok := s.tat[key]
+	if !ok || tat.Before(now) {
+		tat = now
+	}
+	newTat := tat.Add(emissionInterval)
+	diff := now.Sub(newTat.Add(-burstOffset))
+	if diff < 0 {
+		return &RateLimitResult{
+			Limit:      limit.Burst,
+			RetryAfter: -diff,
+			ResetAfter: tat.Sub(now),
+		}, nil
+	}
+	s.tat[key] = newTat
+	return &RateLimitResult{
+		Allowed:    true,
+		Limit:      limit.Burst,
+		Remaining:  int(diff / emissionInterval),
+		ResetAfter: newTat.Sub(now),
+	}, nil
+}
@@ -0,0 +1,86 @@
+package webkit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+// testReplyTransport 记录响应头的 HTTP transport
+type testReplyTransport struct {
+	testHTTPTransport
+	reply http.Header
+}
+
+func (t *testReplyTransport) ReplyHeader() transport.Header { return headerCarrier(t.reply) }
+
+func TestRateLimiterMiddleware(t *testing.T) {
+	now := time.Now()
+	store := NewMemoryRateLimitStore(func() time.Time { return now })
+	limiter, err := NewRateLimiter(store, &RateLimitConfig{Rules: []RateLimitRule{
+		{Name: "login", Operations: []string{"/login"}, Keys: []string{RateLimitKeyUser}, Rate: 2, Period: 60},
+		{Name: "app", Operations: []string{"/api/*"}, Keys: []string{"header:X-App-Id", RateLimitKeyOperation}, Rate: 1, Period: 10},
+	}}, WithRateLimitServerName("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := limiter.Middleware()(func(context.Context, interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	call := func(path, uid, appID string) (http.Header, error) {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.Header.Set("X-App-Id", appID)
+		tr := &testReplyTransport{testHTTPTransport: testHTTPTransport{req: req}, reply: http.Header{}}
+		ctx := transport.NewServerContext(context.Background(), tr)
+		if uid != "" {
+			ctx = NewUserInfoContext(ctx, &UserInfo{UserId: uid})
+		}
+		_, err := handler(ctx, nil)
+		return tr.reply, err
+	}
+
+	tests := []struct {
+		name          string
+		advance       time.Duration
+		path          string
+		uid           string
+		appID         string
+		wantLimited   bool
+		wantRemaining string
+	}{
+		{"first login", 0, "/login", "1", "", false, "1"},
+		{"second login", 0, "/login", "1", "", false, "0"},
+		{"third login limited", 0, "/login", "1", "", true, "0"},
+		{"other user", 0, "/login", "2", "", false, "1"},
+		{"quota recovers", 30 * time.Second, "/login", "1", "", false, "0"},
+		{"unmatched operation", 0, "/probe", "1", "", false, ""},
+		{"app first", 0, "/api/list", "", "a", false, "0"},
+		{"app limited", 0, "/api/list", "", "a", true, "0"},
+		{"app other operation", 0, "/api/detail", "", "a", false, "0"},
+		{"other app", 0, "/api/list", "", "b", false, "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = now.Add(tt.advance)
+			header, err := call(tt.path, tt.uid, tt.appID)
+			if limited := kerrors.Reason(err) == RateLimitReason; limited != tt.wantLimited {
+				t.Fatalf("limited = %v, want %v (err: %v)", limited, tt.wantLimited, err)
+			}
+			if got := header.Get(RateLimitHeaderRemaining); got != tt.wantRemaining {
+				t.Errorf("remaining = %q, want %q", got, tt.wantRemaining)
+			}
+			if tt.wantLimited && header.Get(RateLimitHeaderRetry) == "" {
+				t.Error("limited response should carry Retry-After")
+			}
+		})
+	}
+
+	if err := limiter.Update(&RateLimitConfig{Rules: []RateLimitRule{{Rate: 1, Keys: []string{"cookie"}}}}); err == nil {
+		t.Error("unknown key should fail to compile")
+	}
+}
@@ -3,10 +3,24 @@ package webkit
 import (
 	"context"
 	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
 	CtxApiKey = "ctx_api_name"
+
+	measureTracerName = "github.com/seanbit/kratos/webkit"
+)
+
+// Measure 记录的执行结果
+const (
+	MeasureOutcomeOK    = "ok"
+	MeasureOutcomeError = "error"
+	MeasureOutcomePanic = "panic"
 )
 
 func CtxSetPath(ctx context.Context, api string) context.Context {
@@ -32,7 +46,68 @@ func NewSpan(serviceName string) *Span {
 	return &Span{serviceName}
 }
 
+// EmitCost 记录自 start（Start 的返回值）以来的耗时到 alarm_platform_method_cost，单位秒
+//
+// 注意：该指标此前误记为纳秒，升级后数值缩小1e9，基于它的面板与告警阈值需同步调整
 func (s *Span) EmitCost(ctx context.Context, start int64, proc string) {
-	RecordMethodDurationMetricWithCtx(ctx, s.ServiceName, CtxGetPath(ctx), proc, float64(time.Now().UnixNano()-start))
-	//MethodDurationMetric.WithLabelValues(s.ServiceName, CtxGetPath(ctx), proc).Observe(float64(time.Now().UnixNano() - start))
+	cost := time.Since(time.Unix(0, start)).Seconds()
+	RecordMethodDurationMetricWithCtx(ctx, s.ServiceName, CtxGetPath(ctx), proc, cost)
+}
+
+// Measure 以 s.ServiceName 记录 fn 的耗时，见 Measure
+func (s *Span) Measure(ctx context.Context, proc string, fn func(ctx context.Context) error) error {
+	_, err := measure(ctx, s.ServiceName, proc, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
+
+// Measure 在子 span 中执行 fn，记录耗时与执行结果到 alarm_platform_method_duration_seconds，错误记录到 span 上
+//
+//	err := webkit.Measure(ctx, "query_user", func(ctx context.Context) error {
+//		return repo.QueryUser(ctx, id)
+//	})
+func Measure(ctx context.Context, proc string, fn func(ctx context.Context) error) error {
+	_, err := measure(ctx, metricsServiceName, proc, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
+
+// MeasureValue 同 Measure，返回 fn 的结果
+//
+//	user, err := webkit.MeasureValue(ctx, "query_user", func(ctx context.Context) (*User, error) {
+//		return repo.QueryUser(ctx, id)
+//	})
+func MeasureValue[T any](ctx context.Context, proc string, fn func(ctx context.Context) (T, error)) (T, error) {
+	return measure(ctx, metricsServiceName, proc, fn)
+}
+
+func measure[T any](ctx context.Context, serviceName, proc string, fn func(ctx context.Context) (T, error)) (value T, err error) {
+	operation := CtxGetPath(ctx)
+	if operation == "" {
+		operation = GetOperationFromContext(ctx)
+	}
+	ctx, span := otel.Tracer(measureTracerName).Start(ctx, proc,
+		trace.WithAttributes(attribute.String("operation", operation)),
+	)
+	start := time.Now()
+	outcome := MeasureOutcomePanic
+	defer func() {
+		RecordMethodOutcomeMetricWithCtx(ctx, serviceName, operation, proc, outcome, time.Since(start).Seconds())
+		if outcome == MeasureOutcomePanic {
+			span.SetStatus(codes.Error, "panic")
+		}
+		span.End()
+	}()
+
+	value, err = fn(ctx)
+	if err != nil {
+		outcome = MeasureOutcomeError
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return value, err
+	}
+	outcome = MeasureOutcomeOK
+	return value, nil
 }
@@ -0,0 +1,111 @@
+package webkit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// histogramPoints 返回 name 直方图中包含 attrs 全部标签的数据点
+func histogramPoints(t *testing.T, reader sdkmetric.Reader, name string, attrs ...attribute.KeyValue) []metricdata.HistogramDataPoint[float64] {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	var points []metricdata.HistogramDataPoint[float64]
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+		next:
+			for _, dp := range m.Data.(metricdata.Histogram[float64]).DataPoints {
+				for _, kv := range attrs {
+					if v, ok := dp.Attributes.Value(kv.Key); !ok || v != kv.Value {
+						continue next
+					}
+				}
+				points = append(points, dp)
+			}
+		}
+	}
+	return points
+}
+
+func TestMeasure(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	if err := InitMetrics("webkit_test", WithMetricsExporters(), WithMetricsReader(reader)); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := ShutdownMetrics(context.Background()); err != nil {
+			t.Error(err)
+		}
+	}()
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tracesdk.NewTracerProvider(tracesdk.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	ctx := CtxSetPath(context.Background(), "/login")
+	errQuery := errors.New("query failed")
+	if err := Measure(ctx, "query", func(context.Context) error { return errQuery }); !errors.Is(err, errQuery) {
+		t.Errorf("err = %v, want %v", err, errQuery)
+	}
+	v, err := MeasureValue(ctx, "query", func(context.Context) (int, error) { return 42, nil })
+	if err != nil || v != 42 {
+		t.Errorf("MeasureValue = %d, %v", v, err)
+	}
+	NewSpan("svc").EmitCost(ctx, Start(), "legacy")
+
+	const outcomeMetric = "alarm_platform_method_duration_seconds"
+	for _, outcome := range []string{MeasureOutcomeError, MeasureOutcomeOK} {
+		points := histogramPoints(t, reader, outcomeMetric,
+			attribute.String("service_name", "webkit_test"),
+			attribute.String("operation", "/login"),
+			attribute.String("proc", "query"),
+			attribute.String("outcome", outcome),
+		)
+		if len(points) != 1 || points[0].Count != 1 {
+			t.Errorf("%s points = %v, want one with count 1", outcome, points)
+		}
+	}
+	if points := histogramPoints(t, reader, outcomeMetric, attribute.String("proc", "legacy")); len(points) != 0 {
+		t.Errorf("EmitCost should not record %s", outcomeMetric)
+	}
+
+	// EmitCost 沿用原指标与标签，以秒为单位
+	points := histogramPoints(t, reader, "alarm_platform_method_cost", attribute.String("proc", "legacy"))
+	if len(points) != 1 {
+		t.Fatalf("legacy points = %d, want 1", len(points))
+	}
+	if _, ok := points[0].Attributes.Value("outcome"); ok {
+		t.Error("alarm_platform_method_cost should not have an outcome label")
+	}
+	if sum := points[0].Sum; sum <= 0 || sum > 1 {
+		t.Errorf("EmitCost recorded %v, want seconds", sum)
+	}
+	if points := histogramPoints(t, reader, "alarm_platform_method_cost", attribute.String("proc", "query")); len(points) != 0 {
+		t.Error("Measure should not record alarm_platform_method_cost")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("ended spans = %d, want 2", len(spans))
+	}
+	if spans[0].Name() != "query" || spans[0].Status().Code != codes.Error || len(spans[0].Events()) == 0 {
+		t.Errorf("error span = %s %v, events %d", spans[0].Name(), spans[0].Status(), len(spans[0].Events()))
+	}
+	if spans[1].Status().Code == codes.Error {
+		t.Errorf("ok span status = %v", spans[1].Status())
+	}
+}
@@ -2,61 +2,240 @@ package webkit
 
 import (
 	"context"
-	"fmt"
+	"crypto/tls"
+	"os"
+	"strings"
 
 	"github.com/go-kratos/kratos/v2/log"
-
+	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/exporters/jaeger"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"google.golang.org/grpc/credentials"
+)
+
+// 链路导出方式
+const (
+	// TracerExporterOTLPGRPC 通过 OTLP gRPC 导出，兼容旧配置 "OTLP"
+	TracerExporterOTLPGRPC = "otlp_grpc"
+	// TracerExporterOTLPHTTP 通过 OTLP HTTP 导出
+	TracerExporterOTLPHTTP = "otlp_http"
+	// TracerExporterStdout 输出到标准输出，用于本地调试
+	TracerExporterStdout = "stdout"
+)
+
+// 采样策略
+const (
+	// TracerSamplerParentRatio 有父 span 时跟随父 span，否则按比例采样，默认策略
+	TracerSamplerParentRatio = "parent_ratio"
+	// TracerSamplerRatio 按 trace id 比例采样，忽略父 span
+	TracerSamplerRatio = "ratio"
+	// TracerSamplerAlwaysOn 全部采样
+	TracerSamplerAlwaysOn = "always_on"
+	// TracerSamplerAlwaysOff 全部丢弃
+	TracerSamplerAlwaysOff = "always_off"
 )
 
-// InitTracerProvider  Set global trace provider
-func InitTracerProvider(providerType, host string, port int, serviceName, serviceVersion string, env string) error {
-	if host == "" && port == 0 {
+type tracerOptions struct {
+	exporter    string
+	endpoint    string
+	insecure    bool
+	tlsConfig   *tls.Config
+	headers     map[string]string
+	sampler     string
+	ratio       float64
+	version     string
+	env         string
+	attributes  []attribute.KeyValue
+	propagators []propagation.TextMapPropagator
+	processors  []tracesdk.SpanProcessor
+}
+
+// TracerOption 链路追踪初始化选项
+type TracerOption func(*tracerOptions)
+
+// WithTracerExporter 导出方式，为空时不导出，仅设置 propagator
+func WithTracerExporter(exporter string) TracerOption {
+	return func(o *tracerOptions) {
+		o.exporter = exporter
+	}
+}
+
+// WithTracerEndpoint OTLP 导出地址，host:port 或完整 URL，insecure 为 true 时不使用 TLS
+func WithTracerEndpoint(endpoint string, insecure bool) TracerOption {
+	return func(o *tracerOptions) {
+		o.endpoint = endpoint
+		o.insecure = insecure
+	}
+}
+
+// WithTracerTLSConfig OTLP 导出使用的 TLS 配置，例如自签 CA
+func WithTracerTLSConfig(config *tls.Config) TracerOption {
+	return func(o *tracerOptions) {
+		o.tlsConfig = config
+	}
+}
+
+// WithTracerHeaders OTLP 导出附带的请求头，例如鉴权 token
+func WithTracerHeaders(headers map[string]string) TracerOption {
+	return func(o *tracerOptions) {
+		o.headers = headers
+	}
+}
+
+// WithTracerSampler 采样策略与比例，默认 parent_ratio、比例 1.0
+func WithTracerSampler(sampler string, ratio float64) TracerOption {
+	return func(o *tracerOptions) {
+		o.sampler = sampler
+		o.ratio = ratio
+	}
+}
+
+// WithTracerResource 资源属性中的服务版本与环境，服务名取 InitTracerProvider 的 name
+func WithTracerResource(version, env string, attrs ...attribute.KeyValue) TracerOption {
+	return func(o *tracerOptions) {
+		o.version = version
+		o.env = env
+		o.attributes = append(o.attributes, attrs...)
+	}
+}
+
+// WithTracerPropagators 覆盖全局 propagator，默认 W3C tracecontext 与 baggage
+func WithTracerPropagators(propagators ...propagation.TextMapPropagator) TracerOption {
+	return func(o *tracerOptions) {
+		o.propagators = propagators
+	}
+}
+
+// WithTracerSpanProcessor 追加自定义 SpanProcessor，例如测试使用的 SpanRecorder
+func WithTracerSpanProcessor(processor tracesdk.SpanProcessor) TracerOption {
+	return func(o *tracerOptions) {
+		o.processors = append(o.processors, processor)
+	}
+}
+
+// InitTracerProvider 初始化链路追踪并注册为全局 TracerProvider 与 propagator，
+// 返回的 shutdown 推送缓冲中的 span 并关闭 TracerProvider，应在进程退出前调用
+//
+//	shutdown, err := webkit.InitTracerProvider(Name,
+//		webkit.WithTracerExporter(webkit.TracerExporterOTLPGRPC),
+//		webkit.WithTracerEndpoint("otel-collector:4317", true),
+//	)
+//	defer shutdown(ctx)
+func InitTracerProvider(name string, opts ...TracerOption) (func(ctx context.Context) error, error) {
+	o := &tracerOptions{
+		sampler: TracerSamplerParentRatio,
+		ratio:   1.0,
+		propagators: []propagation.TextMapPropagator{
+			propagation.TraceContext{},
+			propagation.Baggage{},
+		},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(o.propagators...))
+
+	if o.exporter == "" && len(o.processors) == 0 {
 		log.Infow("msg", "trace disabled")
-		return nil
-	}
-
-	var err error
-	var exp tracesdk.SpanExporter
-	switch providerType {
-	case "jaeger":
-		// Create the Jaeger exporter
-		exp, err = jaeger.New(jaeger.WithAgentEndpoint(
-			jaeger.WithAgentHost(host),
-			jaeger.WithAgentPort(fmt.Sprint(port)),
-		))
-	case "OTLP":
-		clientOpts := []otlptracegrpc.Option{
-			otlptracegrpc.WithEndpoint(fmt.Sprintf("%s:%d", host, port)),
-			otlptracegrpc.WithInsecure(),
-		}
-		exp, err = otlptrace.New(context.Background(), otlptracegrpc.NewClient(clientOpts...))
+		return func(context.Context) error { return nil }, nil
 	}
+
+	sampler, err := tracerSampler(o.sampler, o.ratio)
 	if err != nil {
-		return err
-	}
-
-	tp := tracesdk.NewTracerProvider(
-		// Set the sampling rate based on the parent span to 100%
-		tracesdk.WithSampler(tracesdk.ParentBased(tracesdk.TraceIDRatioBased(1.0))),
-		// Always be sure to batch in production.
-		tracesdk.WithBatcher(exp),
-		// Record information about this application in an Resource.
-		tracesdk.WithResource(resource.NewSchemaless(
-			semconv.ServiceNameKey.String(serviceName),
-			semconv.ServiceVersionKey.String(serviceVersion),
-			attribute.String("env", env),
-		)),
-	)
+		return nil, err
+	}
+	providerOpts := []tracesdk.TracerProviderOption{
+		tracesdk.WithSampler(sampler),
+		tracesdk.WithResource(tracerResource(name, o)),
+	}
+	if o.exporter != "" {
+		exp, err := newSpanExporter(o)
+		if err != nil {
+			return nil, errors.Wrapf(err, "init %s trace exporter", o.exporter)
+		}
+		providerOpts = append(providerOpts, tracesdk.WithBatcher(exp))
+	}
+	for _, processor := range o.processors {
+		providerOpts = append(providerOpts, tracesdk.WithSpanProcessor(processor))
+	}
+
+	tp := tracesdk.NewTracerProvider(providerOpts...)
 	otel.SetTracerProvider(tp)
 
-	log.Infof("InitTracerProvider, type: %s, host: %s, port: %d", providerType, host, port)
-	return nil
+	log.Infof("InitTracerProvider, exporter: %s, endpoint: %s, sampler: %s(%v)", o.exporter, o.endpoint, o.sampler, o.ratio)
+	return tp.Shutdown, nil
+}
+
+func tracerSampler(sampler string, ratio float64) (tracesdk.Sampler, error) {
+	switch sampler {
+	case TracerSamplerParentRatio, "":
+		return tracesdk.ParentBased(tracesdk.TraceIDRatioBased(ratio)), nil
+	case TracerSamplerRatio:
+		return tracesdk.TraceIDRatioBased(ratio), nil
+	case TracerSamplerAlwaysOn:
+		return tracesdk.AlwaysSample(), nil
+	case TracerSamplerAlwaysOff:
+		return tracesdk.NeverSample(), nil
+	}
+	return nil, errors.Errorf("unknown trace sampler: %q", sampler)
+}
+
+func tracerResource(name string, o *tracerOptions) *resource.Resource {
+	attrs := []attribute.KeyValue{semconv.ServiceNameKey.String(name)}
+	if o.version != "" {
+		attrs = append(attrs, semconv.ServiceVersionKey.String(o.version))
+	}
+	if o.env != "" {
+		attrs = append(attrs, attribute.String("env", o.env))
+	}
+	attrs = append(attrs, o.attributes...)
+	return resource.NewSchemaless(attrs...)
+}
+
+func newSpanExporter(o *tracerOptions) (tracesdk.SpanExporter, error) {
+	ctx := context.Background()
+	switch strings.ToLower(o.exporter) {
+	case TracerExporterOTLPGRPC, "otlp":
+		var grpcOpts []otlptracegrpc.Option
+		if strings.Contains(o.endpoint, "://") {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithEndpointURL(o.endpoint))
+		} else if o.endpoint != "" {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithEndpoint(o.endpoint))
+		}
+		if o.insecure {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithInsecure())
+		} else if o.tlsConfig != nil {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(o.tlsConfig)))
+		}
+		if len(o.headers) > 0 {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithHeaders(o.headers))
+		}
+		return otlptracegrpc.New(ctx, grpcOpts...)
+	case TracerExporterOTLPHTTP:
+		var httpOpts []otlptracehttp.Option
+		if strings.Contains(o.endpoint, "://") {
+			httpOpts = append(httpOpts, otlptracehttp.WithEndpointURL(o.endpoint))
+		} else if o.endpoint != "" {
+			httpOpts = append(httpOpts, otlptracehttp.WithEndpoint(o.endpoint))
+		}
+		if o.insecure {
+			httpOpts = append(httpOpts, otlptracehttp.WithInsecure())
+		} else if o.tlsConfig != nil {
+			httpOpts = append(httpOpts, otlptracehttp.WithTLSClientConfig(o.tlsConfig))
+		}
+		if len(o.headers) > 0 {
+			httpOpts = append(httpOpts, otlptracehttp.WithHeaders(o.headers))
+		}
+		return otlptracehttp.New(ctx, httpOpts...)
+	case TracerExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+	}
+	return nil, errors.Errorf("unknown trace exporter: %q", o.exporter)
 }
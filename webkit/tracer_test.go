@@ -0,0 +1,91 @@
+package webkit
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInitTracerProvider(t *testing.T) {
+	prevTP, prevProp := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevTP)
+		otel.SetTextMapPropagator(prevProp)
+	})
+
+	for _, opts := range [][]TracerOption{
+		{WithTracerExporter("zipkin")},
+		{WithTracerSpanProcessor(tracetest.NewSpanRecorder()), WithTracerSampler("sometimes", 0.5)},
+	} {
+		if _, err := InitTracerProvider("webkit_test", opts...); err == nil {
+			t.Error("invalid options should fail")
+		}
+	}
+
+	// 未配置导出方式时仅设置 propagator
+	shutdown, err := InitTracerProvider("webkit_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Error(err)
+	}
+	fields := otel.GetTextMapPropagator().Fields()
+	if len(fields) != 3 { // traceparent, tracestate, baggage
+		t.Errorf("propagator fields = %v", fields)
+	}
+
+	tests := []struct {
+		sampler string
+		ratio   float64
+		sampled bool
+	}{
+		{TracerSamplerParentRatio, 1, true},
+		{TracerSamplerRatio, 0, false},
+		{TracerSamplerAlwaysOn, 0, true},
+		{TracerSamplerAlwaysOff, 1, false},
+	}
+	for _, tt := range tests {
+		recorder := tracetest.NewSpanRecorder()
+		shutdown, err := InitTracerProvider("webkit_test",
+			WithTracerSpanProcessor(recorder),
+			WithTracerSampler(tt.sampler, tt.ratio),
+			WithTracerResource("v1.0.0", "test"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, span := otel.Tracer("test").Start(context.Background(), "op")
+		span.End()
+		if got := span.SpanContext().IsSampled(); got != tt.sampled {
+			t.Errorf("%s(%v) sampled = %v, want %v", tt.sampler, tt.ratio, got, tt.sampled)
+		}
+		if err := shutdown(context.Background()); err != nil {
+			t.Error(err)
+		}
+		if tt.sampled && len(recorder.Ended()) != 1 {
+			t.Errorf("%s recorded %d spans", tt.sampler, len(recorder.Ended()))
+		}
+	}
+}
+
+func TestTracePropagation(t *testing.T) {
+	prevProp := otel.GetTextMapPropagator()
+	t.Cleanup(func() { otel.SetTextMapPropagator(prevProp) })
+	if _, err := InitTracerProvider("webkit_test"); err != nil {
+		t.Fatal(err)
+	}
+
+	tp := tracesdk.NewTracerProvider()
+	ctx, span := tp.Tracer("test").Start(context.Background(), "op")
+	defer span.End()
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if carrier.Get("traceparent") == "" {
+		t.Fatalf("traceparent not injected: %v", carrier)
+	}
+}
@@ -0,0 +1,268 @@
+// transport/asynq/admin.go
+package asynq
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// TaskState 任务状态
+type TaskState string
+
+const (
+	TaskStatePending   TaskState = "pending"
+	TaskStateActive    TaskState = "active"
+	TaskStateScheduled TaskState = "scheduled"
+	TaskStateRetry     TaskState = "retry"
+	TaskStateArchived  TaskState = "archived"
+	TaskStateCompleted TaskState = "completed"
+)
+
+// ErrUnsupportedTaskState 该状态不支持此操作
+var ErrUnsupportedTaskState = errors.New("asynq: unsupported task state")
+
+// TaskDetail 管理接口展示的任务，Payload 已去除链路信息
+type TaskDetail struct {
+	*asynq.TaskInfo
+	// JSON 按任务类型解码的 payload，类型未注册或解码失败时为空
+	JSON string
+}
+
+// Admin 基于 asynq.Inspector 的队列管理，供管理接口使用
+type Admin struct {
+	inspector *asynq.Inspector
+}
+
+// NewAdmin 创建队列管理
+func NewAdmin(inspector *asynq.Inspector) *Admin {
+	return &Admin{inspector: inspector}
+}
+
+// Queues 返回全部队列的统计信息，按队列名排序
+func (a *Admin) Queues() ([]*asynq.QueueInfo, error) {
+	names, err := a.inspector.Queues()
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	queues := make([]*asynq.QueueInfo, 0, len(names))
+	for _, name := range names {
+		info, err := a.inspector.GetQueueInfo(name)
+		if err != nil {
+			return nil, err
+		}
+		queues = append(queues, info)
+	}
+	return queues, nil
+}
+
+// Queue 返回单个队列的统计信息
+func (a *Admin) Queue(queue string) (*asynq.QueueInfo, error) {
+	return a.inspector.GetQueueInfo(queue)
+}
+
+// ListTasks 分页列出队列中 state 状态的任务及该状态的任务总数，page 从1开始
+func (a *Admin) ListTasks(queue string, state TaskState, page, pageSize int) ([]*TaskDetail, int, error) {
+	list := map[TaskState]func(string, ...asynq.ListOption) ([]*asynq.TaskInfo, error){
+		TaskStatePending:   a.inspector.ListPendingTasks,
+		TaskStateActive:    a.inspector.ListActiveTasks,
+		TaskStateScheduled: a.inspector.ListScheduledTasks,
+		TaskStateRetry:     a.inspector.ListRetryTasks,
+		TaskStateArchived:  a.inspector.ListArchivedTasks,
+		TaskStateCompleted: a.inspector.ListCompletedTasks,
+	}[state]
+	if list == nil {
+		return nil, 0, errors.Wrap(ErrUnsupportedTaskState, string(state))
+	}
+	info, err := a.inspector.GetQueueInfo(queue)
+	if err != nil {
+		return nil, 0, err
+	}
+	tasks, err := list(queue, asynq.Page(page), asynq.PageSize(pageSize))
+	if err != nil {
+		return nil, 0, err
+	}
+	details := make([]*TaskDetail, 0, len(tasks))
+	for _, task := range tasks {
+		details = append(details, newTaskDetail(task))
+	}
+	return details, countByState(info, state), nil
+}
+
+// GetTask 返回单个任务
+func (a *Admin) GetTask(queue, id string) (*TaskDetail, error) {
+	task, err := a.inspector.GetTaskInfo(queue, id)
+	if err != nil {
+		return nil, err
+	}
+	return newTaskDetail(task), nil
+}
+
+// RunTask 立即执行定时、重试或归档的任务
+func (a *Admin) RunTask(queue, id string) error {
+	return a.inspector.RunTask(queue, id)
+}
+
+// DeleteTask 删除非执行中的任务
+func (a *Admin) DeleteTask(queue, id string) error {
+	return a.inspector.DeleteTask(queue, id)
+}
+
+// ArchiveTask 归档待执行、定时或重试的任务
+func (a *Admin) ArchiveTask(queue, id string) error {
+	return a.inspector.ArchiveTask(queue, id)
+}
+
+// RunTasks 批量执行，ids 为空时执行 state 下的全部任务，返回成功数
+func (a *Admin) RunTasks(queue string, state TaskState, ids []string) (int, error) {
+	if len(ids) > 0 {
+		return a.each(queue, ids, a.inspector.RunTask)
+	}
+	all := map[TaskState]func(string) (int, error){
+		TaskStateScheduled: a.inspector.RunAllScheduledTasks,
+		TaskStateRetry:     a.inspector.RunAllRetryTasks,
+		TaskStateArchived:  a.inspector.RunAllArchivedTasks,
+	}[state]
+	if all == nil {
+		return 0, errors.Wrapf(ErrUnsupportedTaskState, "run all %s tasks", state)
+	}
+	return all(queue)
+}
+
+// DeleteTasks 批量删除，ids 为空时删除 state 下的全部任务，返回成功数
+func (a *Admin) DeleteTasks(queue string, state TaskState, ids []string) (int, error) {
+	if len(ids) > 0 {
+		return a.each(queue, ids, a.inspector.DeleteTask)
+	}
+	all := map[TaskState]func(string) (int, error){
+		TaskStatePending:   a.inspector.DeleteAllPendingTasks,
+		TaskStateScheduled: a.inspector.DeleteAllScheduledTasks,
+		TaskStateRetry:     a.inspector.DeleteAllRetryTasks,
+		TaskStateArchived:  a.inspector.DeleteAllArchivedTasks,
+		TaskStateCompleted: a.inspector.DeleteAllCompletedTasks,
+	}[state]
+	if all == nil {
+		return 0, errors.Wrapf(ErrUnsupportedTaskState, "delete all %s tasks", state)
+	}
+	return all(queue)
+}
+
+// ArchiveTasks 批量归档，ids 为空时归档 state 下的全部任务，返回成功数
+func (a *Admin) ArchiveTasks(queue string, state TaskState, ids []string) (int, error) {
+	if len(ids) > 0 {
+		return a.each(queue, ids, a.inspector.ArchiveTask)
+	}
+	all := map[TaskState]func(string) (int, error){
+		TaskStatePending:   a.inspector.ArchiveAllPendingTasks,
+		TaskStateScheduled: a.inspector.ArchiveAllScheduledTasks,
+		TaskStateRetry:     a.inspector.ArchiveAllRetryTasks,
+	}[state]
+	if all == nil {
+		return 0, errors.Wrapf(ErrUnsupportedTaskState, "archive all %s tasks", state)
+	}
+	return all(queue)
+}
+
+// PauseQueue 暂停队列，暂停期间任务仍可入队但不会被执行
+func (a *Admin) PauseQueue(queue string) error {
+	return a.inspector.PauseQueue(queue)
+}
+
+// UnpauseQueue 恢复队列
+func (a *Admin) UnpauseQueue(queue string) error {
+	return a.inspector.UnpauseQueue(queue)
+}
+
+// each 逐个处理，失败的任务不影响其余任务，返回成功数与第一个错误
+func (a *Admin) each(queue string, ids []string, fn func(queue, id string) error) (int, error) {
+	var n, failed int
+	var first error
+	for _, id := range ids {
+		if err := fn(queue, id); err != nil {
+			if first == nil {
+				first = errors.WithMessage(err, id)
+			}
+			failed++
+			continue
+		}
+		n++
+	}
+	if first != nil {
+		return n, errors.WithMessagef(first, "%d of %d tasks failed", failed, len(ids))
+	}
+	return n, nil
+}
+
+func newTaskDetail(task *asynq.TaskInfo) *TaskDetail {
+	_, payload, _ := unwrapPayload(task.Payload)
+	task.Payload = payload
+	return &TaskDetail{TaskInfo: task, JSON: DecodePayload(task.Type, payload)}
+}
+
+func countByState(info *asynq.QueueInfo, state TaskState) int {
+	switch state {
+	case TaskStatePending:
+		return info.Pending
+	case TaskStateActive:
+		return info.Active
+	case TaskStateScheduled:
+		return info.Scheduled
+	case TaskStateRetry:
+		return info.Retry
+	case TaskStateArchived:
+		return info.Archived
+	case TaskStateCompleted:
+		return info.Completed
+	default:
+		return 0
+	}
+}
+
+// DecodePayload 以任务类型查找已注册的 proto 消息并解码为 JSON，聚合任务解码为数组，无法解码时返回空
+func DecodePayload(typename string, payload []byte) string {
+	_, payload, _ = unwrapPayload(payload)
+	name := strings.TrimPrefix(typename, BatchTypePrefix)
+	msgType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(name))
+	if err != nil {
+		return ""
+	}
+	decode := func(b []byte) (string, bool) {
+		msg := msgType.New().Interface()
+		if err := proto.Unmarshal(b, msg); err != nil {
+			return "", false
+		}
+		data, err := protojson.Marshal(msg)
+		if err != nil {
+			return "", false
+		}
+		return string(data), true
+	}
+	if name == typename {
+		s, _ := decode(payload)
+		return s
+	}
+
+	var items []string
+	for data := payload; len(data) > 0; {
+		b, n := protowire.ConsumeBytes(data)
+		if n < 0 {
+			return ""
+		}
+		s, ok := decode(b)
+		if !ok {
+			return ""
+		}
+		items = append(items, s)
+		data = data[n:]
+	}
+	return fmt.Sprintf("[%s]", strings.Join(items, ","))
+}
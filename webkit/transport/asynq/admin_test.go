@@ -0,0 +1,33 @@
+package asynq
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestDecodePayload(t *testing.T) {
+	a, _ := proto.Marshal(wrapperspb.String("a"))
+	b, _ := proto.Marshal(wrapperspb.String("b"))
+	batch := protowire.AppendBytes(protowire.AppendBytes(nil, a), b)
+
+	tests := []struct {
+		name     string
+		typename string
+		payload  []byte
+		want     string
+	}{
+		{"message", "google.protobuf.StringValue", a, `"a"`},
+		{"batch", BatchTypePrefix + "google.protobuf.StringValue", batch, `["a","b"]`},
+		{"unknown type", "user.login", a, ""},
+		{"bad payload", "google.protobuf.StringValue", []byte{0xff}, ""},
+		{"truncated batch", BatchTypePrefix + "google.protobuf.StringValue", batch[:3], ""},
+	}
+	for _, tt := range tests {
+		if got := DecodePayload(tt.typename, tt.payload); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
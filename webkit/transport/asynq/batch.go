@@ -0,0 +1,59 @@
+// transport/asynq/batch.go
+package asynq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// BatchTypePrefix 聚合任务的类型前缀，聚合任务类型为 BatchTypePrefix + 消息全名
+const BatchTypePrefix = "batch:"
+
+// BatchAggregator 将 PublishGrouped 投递的同组任务聚合为一个任务，payload 为逐条长度前缀的原始 payload
+//
+// 聚合任务在服务端生成，不再携带各任务的链路信息
+//
+//	srv := asynq.NewServer(
+//		asynq.WithGroupAggregator(asynq.BatchAggregator()),
+//		asynq.WithGroupGracePeriod(5*time.Second),
+//		asynq.WithGroupMaxSize(100),
+//	)
+func BatchAggregator() asynq.GroupAggregator {
+	return asynq.GroupAggregatorFunc(func(group string, tasks []*asynq.Task) *asynq.Task {
+		var data []byte
+		for _, task := range tasks {
+			_, payload, _ := unwrapPayload(task.Payload())
+			data = protowire.AppendBytes(data, payload)
+		}
+		return asynq.NewTask(BatchTypePrefix+group, data)
+	})
+}
+
+// HandleBatch 注册 T 类型聚合任务的处理函数，msgs 按入组顺序排列，重复注册会 panic
+//
+// payload 解析失败的任务不会重试
+func HandleBatch[T proto.Message](r *Router, fn func(ctx context.Context, msgs []T) error) {
+	var zero T
+	msgType := zero.ProtoReflect().Type()
+	typename := BatchTypePrefix + string(msgType.Descriptor().FullName())
+	r.handle(typename, asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		var msgs []T
+		for data := Payload(task); len(data) > 0; {
+			b, n := protowire.ConsumeBytes(data)
+			if n < 0 {
+				return fmt.Errorf("decode %s: %w: %w", typename, protowire.ParseError(n), asynq.SkipRetry)
+			}
+			msg := msgType.New().Interface().(T)
+			if err := proto.Unmarshal(b, msg); err != nil {
+				return fmt.Errorf("unmarshal %s: %w: %w", typename, err, asynq.SkipRetry)
+			}
+			msgs = append(msgs, msg)
+			data = data[n:]
+		}
+		return fn(ctx, msgs)
+	}))
+}
@@ -0,0 +1,60 @@
+package asynq
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestBatchAggregator(t *testing.T) {
+	prevProp := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(prevProp) })
+
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+	}))
+	group := "google.protobuf.StringValue"
+	var tasks []*asynq.Task
+	for _, v := range []string{"a", "", "c"} {
+		payload, _ := proto.Marshal(wrapperspb.String(v))
+		data, err := wrapPayload(ctx, payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tasks = append(tasks, asynq.NewTask(group, data))
+	}
+	task := BatchAggregator().Aggregate(group, tasks)
+	if task.Type() != BatchTypePrefix+group {
+		t.Fatalf("type = %s", task.Type())
+	}
+
+	var got []string
+	r := NewRouter()
+	HandleBatch(r, func(ctx context.Context, msgs []*wrapperspb.StringValue) error {
+		for _, msg := range msgs {
+			got = append(got, msg.GetValue())
+		}
+		return nil
+	})
+	if err := r.ProcessTask(context.Background(), task); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 || got[0] != "a" || got[1] != "" || got[2] != "c" {
+		t.Errorf("got %q", got)
+	}
+
+	err := r.ProcessTask(context.Background(), asynq.NewTask(task.Type(), []byte{0x05, 'a'}))
+	if !errors.Is(err, asynq.SkipRetry) {
+		t.Errorf("truncated payload: err = %v, want skip retry", err)
+	}
+}
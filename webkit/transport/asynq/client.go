@@ -0,0 +1,135 @@
+// transport/asynq/client.go
+package asynq
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+// Client 将链路信息随任务投递的 asynq 客户端，消费端由 Server 还原
+//
+// 链路信息写在 payload 前部，消费端须通过 Handle 注册或以 Payload(task) 读取 payload
+type Client struct {
+	client *asynq.Client
+}
+
+// NewClient 包装 asynq 客户端
+func NewClient(client *asynq.Client) *Client {
+	return &Client{client: client}
+}
+
+// Enqueue 在生产 span 中投递任务，并将 ctx 中的 trace context 与 baggage 写入任务
+//
+// 任务选项通过 opts 传入，延迟投递用 asynq.ProcessIn、asynq.ProcessAt，去重用 asynq.Unique 或 asynq.TaskID；
+// asynq.Unique 以 payload 去重，而携带链路信息的 payload 每次不同，因此改以原始 payload 计算的 TaskID 去重，
+// 重复时仍返回 asynq.ErrDuplicateTask，详见 uniqueOptions
+func (c *Client) Enqueue(ctx context.Context, typename string, payload []byte, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	attrs := []attribute.KeyValue{
+		attribute.String("messaging.system", "asynq"),
+		attribute.String("messaging.operation", "publish"),
+	}
+	ctx, span := otel.Tracer(tracerName).Start(ctx, typename+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(attrs...),
+	)
+	defer span.End()
+
+	data, err := wrapPayload(ctx, payload)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	opts, unique := uniqueOptions(typename, payload, opts)
+	info, err := c.client.EnqueueContext(ctx, asynq.NewTask(typename, data), opts...)
+	if unique && errors.Is(err, asynq.ErrTaskIDConflict) {
+		err = asynq.ErrDuplicateTask
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(
+		attribute.String("messaging.message.id", info.ID),
+		attribute.String("messaging.destination.name", info.Queue),
+	)
+	return info, nil
+}
+
+// Publish 以 proto.MessageName(msg) 为任务类型投递 msg，由 Router 中 Handle 注册的处理函数消费
+func (c *Client) Publish(ctx context.Context, msg proto.Message, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return c.Enqueue(ctx, string(proto.MessageName(msg)), payload, opts...)
+}
+
+// PublishGrouped 投递到以消息类型命名的组，由 BatchAggregator 聚合后交给 HandleBatch 注册的处理函数
+//
+// 服务端须通过 WithGroupAggregator(BatchAggregator()) 开启聚合
+func (c *Client) PublishGrouped(ctx context.Context, msg proto.Message, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	return c.Publish(ctx, msg, append(opts, asynq.Group(string(proto.MessageName(msg))))...)
+}
+
+// uniqueOptions 将 asynq.Unique(ttl) 替换为以队列、类型与原始 payload 摘要为 ID 的 asynq.TaskID，
+// 并以 asynq.Retention(ttl) 保留已完成的任务（未指定 Retention 时）
+//
+// 与 asynq.Unique 的区别：去重持续到任务完成后 ttl，而非投递后 ttl；归档的任务删除前一直占用 ID。
+// 已指定 TaskID 时不做替换
+func uniqueOptions(typename string, payload []byte, opts []asynq.Option) ([]asynq.Option, bool) {
+	var ttl time.Duration
+	queue, retention := "default", false
+	for _, opt := range opts {
+		switch opt.Type() {
+		case asynq.TaskIDOpt:
+			return opts, false
+		case asynq.UniqueOpt:
+			ttl = opt.Value().(time.Duration)
+		case asynq.QueueOpt:
+			queue = opt.Value().(string)
+		case asynq.RetentionOpt:
+			retention = true
+		}
+	}
+	if ttl <= 0 {
+		return opts, false
+	}
+	h := sha256.New()
+	for _, part := range [][]byte{[]byte(queue), []byte(typename), payload} {
+		h.Write(part)
+		h.Write([]byte{0})
+	}
+	replaced := make([]asynq.Option, 0, len(opts)+1)
+	for _, opt := range opts {
+		if opt.Type() != asynq.UniqueOpt {
+			replaced = append(replaced, opt)
+		}
+	}
+	replaced = append(replaced, asynq.TaskID("unique:"+hex.EncodeToString(h.Sum(nil))))
+	if !retention {
+		replaced = append(replaced, asynq.Retention(ttl))
+	}
+	return replaced, true
+}
+
+// Client 返回原始 asynq 客户端，投递的任务不携带链路信息
+func (c *Client) Client() *asynq.Client {
+	return c.client
+}
+
+// Close 关闭客户端
+func (c *Client) Close() error {
+	return c.client.Close()
+}
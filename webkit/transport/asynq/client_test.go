@@ -0,0 +1,41 @@
+package asynq
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+func TestUniqueOptions(t *testing.T) {
+	optTypes := func(opts []asynq.Option) map[asynq.OptionType]interface{} {
+		m := make(map[asynq.OptionType]interface{})
+		for _, opt := range opts {
+			m[opt.Type()] = opt.Value()
+		}
+		return m
+	}
+
+	opts, unique := uniqueOptions("user.login", []byte("a"), []asynq.Option{asynq.Unique(time.Minute), asynq.MaxRetry(3)})
+	got := optTypes(opts)
+	if !unique || got[asynq.UniqueOpt] != nil || got[asynq.TaskIDOpt] == nil || got[asynq.RetentionOpt] != time.Minute || got[asynq.MaxRetryOpt] != 3 {
+		t.Errorf("unique options = %v", opts)
+	}
+	again, _ := uniqueOptions("user.login", []byte("a"), []asynq.Option{asynq.Unique(time.Hour)})
+	other, _ := uniqueOptions("user.login", []byte("a"), []asynq.Option{asynq.Unique(time.Hour), asynq.Queue("low")})
+	if optTypes(again)[asynq.TaskIDOpt] != got[asynq.TaskIDOpt] {
+		t.Error("same queue, type and payload should have the same task id")
+	}
+	if optTypes(other)[asynq.TaskIDOpt] == got[asynq.TaskIDOpt] {
+		t.Error("different queue should have a different task id")
+	}
+
+	for _, opts := range [][]asynq.Option{
+		{asynq.MaxRetry(3)},
+		{asynq.Unique(time.Minute), asynq.TaskID("id")},
+	} {
+		if replaced, unique := uniqueOptions("user.login", nil, opts); unique || len(replaced) != len(opts) {
+			t.Errorf("%v should not be replaced", opts)
+		}
+	}
+}
@@ -2,7 +2,10 @@
 package asynq
 
 import (
+	"time"
+
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/hibiken/asynq"
 )
 
@@ -13,6 +16,13 @@ type Config struct {
 	Queues      map[string]int `json:"queues"`
 	Logger      log.Logger     `json:"-"`
 	Handler     asynq.Handler  `json:"-"`
+	// Middleware 任务处理的中间件链，与 HTTP/gRPC 服务共用
+	Middleware []middleware.Middleware `json:"-"`
+	// GroupAggregator 将同组任务聚合为一个任务，为空时不聚合
+	GroupAggregator  asynq.GroupAggregator `json:"-"`
+	GroupGracePeriod time.Duration         `json:"group_grace_period"`
+	GroupMaxDelay    time.Duration         `json:"group_max_delay"`
+	GroupMaxSize     int                   `json:"group_max_size"`
 }
 
 // ServerOption Asynq 服务器选项
@@ -51,3 +61,38 @@ func WithHandler(h asynq.Handler) ServerOption {
 		c.Handler = h
 	}
 }
+
+// WithMiddleware 设置任务处理的中间件，例如 webkit.ServerLogging、tracing.Server、recovery.Recovery
+func WithMiddleware(m ...middleware.Middleware) ServerOption {
+	return func(c *Config) {
+		c.Middleware = m
+	}
+}
+
+// WithGroupAggregator 设置任务组聚合函数，例如 BatchAggregator()
+func WithGroupAggregator(aggregator asynq.GroupAggregator) ServerOption {
+	return func(c *Config) {
+		c.GroupAggregator = aggregator
+	}
+}
+
+// WithGroupGracePeriod 组内最后一个任务入组后等待的时长，期间有新任务入组则重新计时，默认1分钟
+func WithGroupGracePeriod(d time.Duration) ServerOption {
+	return func(c *Config) {
+		c.GroupGracePeriod = d
+	}
+}
+
+// WithGroupMaxDelay 组内第一个任务入组后最多等待的时长，0 表示不限制
+func WithGroupMaxDelay(d time.Duration) ServerOption {
+	return func(c *Config) {
+		c.GroupMaxDelay = d
+	}
+}
+
+// WithGroupMaxSize 组内任务数达到该值时立即聚合，0 表示不限制
+func WithGroupMaxSize(size int) ServerOption {
+	return func(c *Config) {
+		c.GroupMaxSize = size
+	}
+}
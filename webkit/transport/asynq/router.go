@@ -0,0 +1,129 @@
+// transport/asynq/router.go
+package asynq
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// UnknownTaskPolicy 未注册任务类型的处理策略
+type UnknownTaskPolicy int
+
+const (
+	// UnknownTaskSkip 记录日志后视为处理成功
+	UnknownTaskSkip UnknownTaskPolicy = iota
+	// UnknownTaskArchive 不重试，直接归档，可在 asynq 管理后台查看
+	UnknownTaskArchive
+	// UnknownTaskError 返回错误，按任务的重试策略重试
+	UnknownTaskError
+)
+
+// ErrUnknownTask 任务类型未注册
+var ErrUnknownTask = errors.New("asynq: unknown task type")
+
+// Router 以 proto 消息全名为任务类型分发任务，自动反序列化 payload
+//
+//	router := asynq.NewRouter(asynq.WithUnknownTaskPolicy(asynq.UnknownTaskArchive))
+//	asynq.Handle(router, func(ctx context.Context, msg *event.UserLogin) error {
+//		return auth.SaveUserLoginLog(ctx, ...)
+//	})
+//	srv := asynq.NewServer(asynq.WithHandler(router))
+type Router struct {
+	mu       sync.RWMutex
+	handlers map[string]asynq.Handler
+	policy   UnknownTaskPolicy
+	logger   *log.Helper
+}
+
+// RouterOption Router 选项
+type RouterOption func(*Router)
+
+// WithUnknownTaskPolicy 设置未注册任务类型的处理策略，默认 UnknownTaskSkip
+func WithUnknownTaskPolicy(policy UnknownTaskPolicy) RouterOption {
+	return func(r *Router) {
+		r.policy = policy
+	}
+}
+
+// WithRouterLogger 设置日志
+func WithRouterLogger(logger log.Logger) RouterOption {
+	return func(r *Router) {
+		r.logger = log.NewHelper(logger)
+	}
+}
+
+// NewRouter 创建任务路由
+func NewRouter(opts ...RouterOption) *Router {
+	r := &Router{
+		handlers: make(map[string]asynq.Handler),
+		logger:   log.NewHelper(log.GetLogger()),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Handle 注册 T 类型任务的处理函数，任务类型为 proto.MessageName(T)，重复注册会 panic
+//
+// payload 反序列化失败的任务不会重试
+func Handle[T proto.Message](r *Router, fn func(ctx context.Context, msg T) error) {
+	var zero T
+	msgType := zero.ProtoReflect().Type()
+	typename := string(msgType.Descriptor().FullName())
+	r.handle(typename, asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		msg := msgType.New().Interface().(T)
+		if err := proto.Unmarshal(Payload(task), msg); err != nil {
+			return fmt.Errorf("unmarshal %s: %w: %w", typename, err, asynq.SkipRetry)
+		}
+		return fn(ctx, msg)
+	}))
+}
+
+// HandleFunc 注册 typename 任务的处理函数，task 原样传入，payload 通过 Payload(task) 读取，重复注册会 panic
+func (r *Router) HandleFunc(typename string, fn func(ctx context.Context, task *asynq.Task) error) {
+	r.handle(typename, asynq.HandlerFunc(fn))
+}
+
+// Registered typename 是否已注册处理函数
+func (r *Router) Registered(typename string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.handlers[typename]
+	return ok
+}
+
+func (r *Router) handle(typename string, h asynq.Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.handlers[typename]; ok {
+		panic("asynq: multiple registrations for " + typename)
+	}
+	r.handlers[typename] = h
+}
+
+// ProcessTask 实现 asynq.Handler
+func (r *Router) ProcessTask(ctx context.Context, task *asynq.Task) error {
+	r.mu.RLock()
+	h, ok := r.handlers[task.Type()]
+	r.mu.RUnlock()
+	if ok {
+		return h.ProcessTask(ctx, task)
+	}
+
+	switch r.policy {
+	case UnknownTaskArchive:
+		return fmt.Errorf("%w %q: %w", ErrUnknownTask, task.Type(), asynq.SkipRetry)
+	case UnknownTaskError:
+		return errors.Wrap(ErrUnknownTask, task.Type())
+	default:
+		r.logger.WithContext(ctx).Warnf("asynq: skip unknown task type %q", task.Type())
+		return nil
+	}
+}
@@ -0,0 +1,61 @@
+package asynq
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestRouter(t *testing.T) {
+	payload, _ := proto.Marshal(wrapperspb.String("hello"))
+	traced := append(append([]byte{}, envelopeMagic...), 2, '{', '}')
+	traced = append(traced, payload...)
+	var got string
+
+	tests := []struct {
+		name      string
+		policy    UnknownTaskPolicy
+		task      *asynq.Task
+		want      string
+		unknown   bool
+		skipRetry bool
+	}{
+		{"dispatch", UnknownTaskError, asynq.NewTask("google.protobuf.StringValue", payload), "hello", false, false},
+		{"traced", UnknownTaskError, asynq.NewTask("google.protobuf.StringValue", traced), "hello", false, false},
+		{"bad payload", UnknownTaskError, asynq.NewTask("google.protobuf.StringValue", []byte{0xff}), "", false, true},
+		{"unknown skip", UnknownTaskSkip, asynq.NewTask("user.logout", nil), "", false, false},
+		{"unknown archive", UnknownTaskArchive, asynq.NewTask("user.logout", nil), "", true, true},
+		{"unknown error", UnknownTaskError, asynq.NewTask("user.logout", nil), "", true, false},
+	}
+	for _, tt := range tests {
+		got = ""
+		r := NewRouter(WithUnknownTaskPolicy(tt.policy))
+		Handle(r, func(ctx context.Context, msg *wrapperspb.StringValue) error {
+			got = msg.GetValue()
+			return nil
+		})
+		err := r.ProcessTask(context.Background(), tt.task)
+		if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+		if errors.Is(err, ErrUnknownTask) != tt.unknown {
+			t.Errorf("%s: err = %v, unknown %v", tt.name, err, tt.unknown)
+		}
+		if errors.Is(err, asynq.SkipRetry) != tt.skipRetry {
+			t.Errorf("%s: err = %v, skip retry %v", tt.name, err, tt.skipRetry)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("duplicate registration should panic")
+		}
+	}()
+	r := NewRouter()
+	Handle(r, func(context.Context, *wrapperspb.StringValue) error { return nil })
+	Handle(r, func(context.Context, *wrapperspb.StringValue) error { return nil })
+}
@@ -0,0 +1,164 @@
+// transport/asynq/scheduler.go
+package asynq
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+)
+
+var _ transport.Server = (*Scheduler)(nil)
+
+// PeriodicTask 周期任务，到期时由 Scheduler 投递，由 Server 消费
+type PeriodicTask interface {
+	// Spec cron 表达式（分钟级，不含秒）或 "@every 30s"
+	Spec() string
+	Task() *asynq.Task
+	// Options 任务选项，多实例部署时应包含 asynq.Unique 以免重复投递
+	Options() []asynq.Option
+}
+
+// PeriodicTaskRegister 声明周期任务，用法同 crontab.JobRegister
+type PeriodicTaskRegister interface {
+	PeriodicTasks() []PeriodicTask
+}
+
+type periodicTask struct {
+	spec string
+	task *asynq.Task
+	opts []asynq.Option
+}
+
+// NewPeriodicTask 创建周期任务
+func NewPeriodicTask(spec string, task *asynq.Task, opts ...asynq.Option) PeriodicTask {
+	return &periodicTask{spec: spec, task: task, opts: opts}
+}
+
+func (t *periodicTask) Spec() string { return t.spec }
+
+func (t *periodicTask) Task() *asynq.Task { return t.task }
+
+func (t *periodicTask) Options() []asynq.Option { return t.opts }
+
+// SchedulerConfig Scheduler 配置
+type SchedulerConfig struct {
+	RedisURI string               `json:"redis_uri"`
+	Location *time.Location       `json:"-"`
+	Logger   log.Logger           `json:"-"`
+	Register PeriodicTaskRegister `json:"-"`
+}
+
+// SchedulerOption Scheduler 选项
+type SchedulerOption func(*SchedulerConfig)
+
+// WithSchedulerRedisURI 设置 Redis 配置
+func WithSchedulerRedisURI(uri string) SchedulerOption {
+	return func(c *SchedulerConfig) {
+		c.RedisURI = uri
+	}
+}
+
+// WithSchedulerLocation 设置 cron 表达式的时区，默认 time.Local
+func WithSchedulerLocation(loc *time.Location) SchedulerOption {
+	return func(c *SchedulerConfig) {
+		c.Location = loc
+	}
+}
+
+// WithSchedulerLogger 设置日志
+func WithSchedulerLogger(logger log.Logger) SchedulerOption {
+	return func(c *SchedulerConfig) {
+		c.Logger = logger
+	}
+}
+
+// WithPeriodicTasks 设置周期任务
+func WithPeriodicTasks(register PeriodicTaskRegister) SchedulerOption {
+	return func(c *SchedulerConfig) {
+		c.Register = register
+	}
+}
+
+// Scheduler 基于 asynq.Scheduler 的周期任务投递服务
+//
+// 与 crontab 不同，任务投递到队列后由任一 Server 实例消费，可重试并在管理后台查看
+type Scheduler struct {
+	*asynq.Scheduler
+
+	config *SchedulerConfig
+	logger *log.Helper
+
+	once sync.Once
+}
+
+// NewScheduler 创建周期任务投递服务
+func NewScheduler(opts ...SchedulerOption) *Scheduler {
+	config := &SchedulerConfig{
+		RedisURI: "redis://127.0.0.1:6379/9",
+		Location: time.Local,
+		Logger:   log.GetLogger(),
+	}
+
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return &Scheduler{
+		config: config,
+		logger: log.NewHelper(config.Logger),
+	}
+}
+
+// Start 注册周期任务并启动调度
+func (s *Scheduler) Start(ctx context.Context) error {
+	redisConnOpts, err := asynq.ParseRedisURI(s.config.RedisURI)
+	if err != nil {
+		return err
+	}
+	s.once.Do(func() {
+		s.Scheduler = asynq.NewScheduler(redisConnOpts, &asynq.SchedulerOpts{
+			Logger:          NewAsynqLogger(s.logger),
+			Location:        s.config.Location,
+			PostEnqueueFunc: s.postEnqueue,
+		})
+	})
+
+	if s.config.Register != nil {
+		for _, task := range s.config.Register.PeriodicTasks() {
+			entryID, err := s.Register(task.Spec(), task.Task(), task.Options()...)
+			if err != nil {
+				return errors.Wrapf(err, "asynq.Scheduler.Register(%s)", task.Task().Type())
+			}
+			s.logger.Infof("asynq.Scheduler.Register(%s) success:%s", task.Task().Type(), entryID)
+		}
+	}
+
+	s.logger.Infof("Asynq scheduler starting with: %s", redactURI(s.config.RedisURI))
+	return s.Scheduler.Start()
+}
+
+// Stop 停止调度
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.logger.Info("Asynq scheduler stopping")
+	if s.Scheduler != nil {
+		s.Scheduler.Shutdown()
+	}
+	s.logger.Info("Asynq scheduler stopped")
+	return nil
+}
+
+// postEnqueue 多实例同时投递时 asynq.Unique 去重属预期行为，不记为错误
+func (s *Scheduler) postEnqueue(info *asynq.TaskInfo, err error) {
+	switch {
+	case err == nil:
+	case errors.Is(err, asynq.ErrDuplicateTask), errors.Is(err, asynq.ErrTaskIDConflict):
+		s.logger.Debugf("asynq scheduler skip duplicate task: %v", err)
+	default:
+		s.logger.Errorf("asynq scheduler enqueue error: %v", err)
+	}
+}
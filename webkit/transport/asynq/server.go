@@ -3,9 +3,11 @@ package asynq
 
 import (
 	"context"
+	"net/url"
 	"sync"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/transport"
 	"github.com/hibiken/asynq"
 )
@@ -56,9 +58,13 @@ func (s *Server) Start(ctx context.Context) error {
 		s.Server = asynq.NewServer(
 			redisConnOpts,
 			asynq.Config{
-				Concurrency: s.config.Concurrency,
-				Queues:      s.config.Queues,
-				Logger:      NewAsynqLogger(s.logger),
+				Concurrency:      s.config.Concurrency,
+				Queues:           s.config.Queues,
+				Logger:           NewAsynqLogger(s.logger),
+				GroupAggregator:  s.config.GroupAggregator,
+				GroupGracePeriod: s.config.GroupGracePeriod,
+				GroupMaxDelay:    s.config.GroupMaxDelay,
+				GroupMaxSize:     s.config.GroupMaxSize,
 			},
 		)
 
@@ -68,8 +74,8 @@ func (s *Server) Start(ctx context.Context) error {
 
 	// 在 goroutine 中启动服务器
 	go func() {
-		s.logger.Infof("Asynq server starting with: %s", s.config.RedisURI)
-		if err := s.Server.Start(s.config.Handler); err != nil {
+		s.logger.Infof("Asynq server starting with: %s", redactURI(s.config.RedisURI))
+		if err := s.Server.Start(s.handler()); err != nil {
 			s.logger.Errorf("Asynq server run error: %v", err)
 		}
 	}()
@@ -92,6 +98,31 @@ func (s *Server) Stop(ctx context.Context) error {
 	return nil
 }
 
+// handler 每个任务以独立的 Transport 写入 ctx 并经过中间件链，最外层还原生产端的链路信息
+func (s *Server) handler() asynq.Handler {
+	endpoint := redactURI(s.config.RedisURI)
+	next := middleware.Handler(func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, s.config.Handler.ProcessTask(ctx, req.(*asynq.Task))
+	})
+	if len(s.config.Middleware) > 0 {
+		next = middleware.Chain(s.config.Middleware...)(next)
+	}
+	return tracingHandler(asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		ctx = transport.NewServerContext(ctx, newTransport(ctx, endpoint, task))
+		_, err := next(ctx, task)
+		return err
+	}))
+}
+
+// redactURI 去除 Redis 地址中的密码
+func redactURI(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return ""
+	}
+	return u.Redacted()
+}
+
 // Client 获取 Asynq 客户端
 func (s *Server) Client() *asynq.Client {
 	return s.client
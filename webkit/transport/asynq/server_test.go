@@ -0,0 +1,52 @@
+package asynq
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/hibiken/asynq"
+)
+
+func TestServerHandler(t *testing.T) {
+	errTask := errors.New("task failed")
+	var calls []string
+	record := func(name string) middleware.Middleware {
+		return func(next middleware.Handler) middleware.Handler {
+			return func(ctx context.Context, req interface{}) (interface{}, error) {
+				tr, ok := transport.FromServerContext(ctx)
+				if !ok || tr.Kind() != KindASYNQ || tr.Operation() != "user.login" {
+					t.Errorf("%s: transport = %v", name, tr)
+				}
+				calls = append(calls, name)
+				return next(ctx, req)
+			}
+		}
+	}
+	s := NewServer(
+		WithRedisURI("redis://:secret@127.0.0.1:6379/9"),
+		WithMiddleware(record("first"), record("second")),
+		WithHandler(asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+			calls = append(calls, "handler")
+			return errTask
+		})),
+	)
+
+	err := s.handler().ProcessTask(context.Background(), asynq.NewTask("user.login", nil))
+	if !errors.Is(err, errTask) {
+		t.Errorf("err = %v, want %v", err, errTask)
+	}
+	if len(calls) != 3 || calls[0] != "first" || calls[1] != "second" || calls[2] != "handler" {
+		t.Errorf("calls = %v", calls)
+	}
+
+	tr := newTransport(context.Background(), redactURI(s.config.RedisURI), asynq.NewTask("user.login", nil))
+	if tr.Endpoint() != "redis://:xxxxx@127.0.0.1:6379/9" {
+		t.Errorf("endpoint = %s", tr.Endpoint())
+	}
+	if tr.TaskID() != "" || tr.RetryCount() != 0 {
+		t.Errorf("task id = %q, retry = %d outside asynq", tr.TaskID(), tr.RetryCount())
+	}
+}
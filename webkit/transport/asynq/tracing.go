@@ -0,0 +1,105 @@
+// transport/asynq/tracing.go
+package asynq
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/seanbit/kratos/webkit/transport/asynq"
+
+// envelopeMagic 携带链路信息的 payload 前缀，proto 与 JSON 编码均不会以 0x00 开头
+var envelopeMagic = []byte{0x00, 'w', 'k', 0x01}
+
+// wrapPayload 将 ctx 中的 trace context 与 baggage 写入 payload：magic | uvarint(len) | carrier(JSON) | payload
+func wrapPayload(ctx context.Context, payload []byte) ([]byte, error) {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return payload, nil
+	}
+	header, err := json.Marshal(carrier)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 0, len(envelopeMagic)+binary.MaxVarintLen64+len(header)+len(payload))
+	buf = append(buf, envelopeMagic...)
+	buf = binary.AppendUvarint(buf, uint64(len(header)))
+	buf = append(buf, header...)
+	return append(buf, payload...), nil
+}
+
+// unwrapPayload 拆出链路信息与原始 payload，不带链路信息的 payload 原样返回
+func unwrapPayload(data []byte) (propagation.MapCarrier, []byte, bool) {
+	if !bytes.HasPrefix(data, envelopeMagic) {
+		return nil, data, false
+	}
+	rest := data[len(envelopeMagic):]
+	n, size := binary.Uvarint(rest)
+	if size <= 0 || uint64(len(rest)-size) < n {
+		return nil, data, false
+	}
+	carrier := propagation.MapCarrier{}
+	if err := json.Unmarshal(rest[size:size+int(n)], &carrier); err != nil {
+		return nil, data, false
+	}
+	return carrier, rest[size+int(n):], true
+}
+
+// Payload 返回去除链路信息后的原始 payload
+//
+// 经 Client 投递的任务 payload 带有链路信息，未通过 Handle、HandleBatch 注册的处理函数应以此代替 task.Payload()
+func Payload(task *asynq.Task) []byte {
+	_, payload, _ := unwrapPayload(task.Payload())
+	return payload
+}
+
+// tracingHandler 还原生产端的链路信息，并以生产端 span 为父节点开启消费 span
+//
+// task 原样传给下游以保留 ResultWriter，payload 通过 Payload(task) 读取
+func tracingHandler(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		if carrier, _, ok := unwrapPayload(task.Payload()); ok {
+			ctx = otel.GetTextMapPropagator().Extract(ctx, carrier)
+		}
+
+		attrs := []attribute.KeyValue{
+			attribute.String("messaging.system", "asynq"),
+			attribute.String("messaging.operation", "process"),
+		}
+		if id, ok := asynq.GetTaskID(ctx); ok {
+			attrs = append(attrs, attribute.String("messaging.message.id", id))
+		}
+		if queue, ok := asynq.GetQueueName(ctx); ok {
+			attrs = append(attrs, attribute.String("messaging.destination.name", queue))
+		}
+		if retry, ok := asynq.GetRetryCount(ctx); ok {
+			attrs = append(attrs, attribute.Int("messaging.asynq.retry_count", retry))
+		}
+		opts := []trace.SpanStartOption{
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(attrs...),
+		}
+		if producer := trace.SpanContextFromContext(ctx); producer.IsValid() {
+			opts = append(opts, trace.WithLinks(trace.Link{SpanContext: producer}))
+		}
+		ctx, span := otel.Tracer(tracerName).Start(ctx, task.Type()+" process", opts...)
+		defer span.End()
+
+		err := next.ProcessTask(ctx, task)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return err
+	})
+}
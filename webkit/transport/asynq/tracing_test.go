@@ -0,0 +1,91 @@
+package asynq
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracingHandler(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prevTP, prevProp := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevTP)
+		otel.SetTextMapPropagator(prevProp)
+	})
+
+	member, _ := baggage.NewMember("user_id", "42")
+	bag, _ := baggage.New(member)
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+	ctx, producer := otel.Tracer("test").Start(ctx, "login")
+	payload := []byte("payload")
+	data, err := wrapPayload(ctx, payload)
+	producer.End()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		data   []byte
+		parent trace.SpanContext
+	}{
+		{"traced", data, producer.SpanContext()},
+		{"plain", payload, trace.SpanContext{}},
+		{"truncated", envelopeMagic, trace.SpanContext{}},
+	}
+	for _, tt := range tests {
+		var got []byte
+		var gotBaggage string
+		var gotTask *asynq.Task
+		h := tracingHandler(asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+			got = Payload(task)
+			gotTask = task
+			gotBaggage = baggage.FromContext(ctx).Member("user_id").Value()
+			return nil
+		}))
+		task := asynq.NewTask("user.login", tt.data)
+		if err := h.ProcessTask(context.Background(), task); err != nil {
+			t.Fatal(err)
+		}
+		if gotTask != task {
+			t.Errorf("%s: task should be passed through to keep its ResultWriter", tt.name)
+		}
+		spans := recorder.Ended()
+		consumer := spans[len(spans)-1]
+		if consumer.SpanKind() != trace.SpanKindConsumer || consumer.Name() != "user.login process" {
+			t.Errorf("%s: consumer span = %s %v", tt.name, consumer.Name(), consumer.SpanKind())
+		}
+		if !tt.parent.IsValid() {
+			if consumer.Parent().IsValid() {
+				t.Errorf("%s: unexpected parent %v", tt.name, consumer.Parent())
+			}
+			if !bytes.Equal(got, tt.data) {
+				t.Errorf("%s: payload = %q, want %q", tt.name, got, tt.data)
+			}
+			continue
+		}
+		if !bytes.Equal(got, payload) {
+			t.Errorf("%s: payload = %q, want %q", tt.name, got, payload)
+		}
+		if consumer.Parent().SpanID() != tt.parent.SpanID() || consumer.SpanContext().TraceID() != tt.parent.TraceID() {
+			t.Errorf("%s: consumer parent = %v, want %v", tt.name, consumer.Parent(), tt.parent)
+		}
+		if links := consumer.Links(); len(links) != 1 || links[0].SpanContext.SpanID() != tt.parent.SpanID() {
+			t.Errorf("%s: links = %v", tt.name, links)
+		}
+		if gotBaggage != "42" {
+			t.Errorf("%s: baggage user_id = %q", tt.name, gotBaggage)
+		}
+	}
+}
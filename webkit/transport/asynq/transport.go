@@ -2,40 +2,112 @@
 package asynq
 
 import (
+	"context"
+	"net/http"
+	"strconv"
+
 	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/hibiken/asynq"
 )
 
 const KindASYNQ transport.Kind = "asynq"
 
-var _ transport.Transporter = (*Server)(nil)
+// 任务信息在 RequestHeader 中的键
+const (
+	HeaderTaskID     = "X-Asynq-Task-Id"
+	HeaderQueue      = "X-Asynq-Queue"
+	HeaderRetryCount = "X-Asynq-Retry-Count"
+	HeaderMaxRetry   = "X-Asynq-Max-Retry"
+)
+
+var _ transport.Transporter = (*Transport)(nil)
+
+// Transport 单个任务的 Transporter，Operation 为任务类型
+type Transport struct {
+	endpoint    string
+	operation   string
+	reqHeader   headerCarrier
+	replyHeader headerCarrier
+}
+
+// newTransport 由 asynq 写入 ctx 的任务信息构造 Transport
+func newTransport(ctx context.Context, endpoint string, task *asynq.Task) *Transport {
+	header := headerCarrier{}
+	if id, ok := asynq.GetTaskID(ctx); ok {
+		header.Set(HeaderTaskID, id)
+	}
+	if queue, ok := asynq.GetQueueName(ctx); ok {
+		header.Set(HeaderQueue, queue)
+	}
+	if retry, ok := asynq.GetRetryCount(ctx); ok {
+		header.Set(HeaderRetryCount, strconv.Itoa(retry))
+	}
+	if maxRetry, ok := asynq.GetMaxRetry(ctx); ok {
+		header.Set(HeaderMaxRetry, strconv.Itoa(maxRetry))
+	}
+	return &Transport{
+		endpoint:    endpoint,
+		operation:   task.Type(),
+		reqHeader:   header,
+		replyHeader: headerCarrier{},
+	}
+}
 
 // Kind 返回传输类型
-func (s *Server) Kind() transport.Kind {
+func (tr *Transport) Kind() transport.Kind {
 	return KindASYNQ
 }
 
-// Endpoint 返回终端地址
-func (s *Server) Endpoint() string {
-	if s.config == nil {
-		return ""
-	}
-	return s.config.RedisURI
+// Endpoint 返回 Redis 地址
+func (tr *Transport) Endpoint() string {
+	return tr.endpoint
 }
 
-// Operation 返回操作名称
-func (s *Server) Operation() string {
-	return "asynq"
+// Operation 返回任务类型
+func (tr *Transport) Operation() string {
+	return tr.operation
 }
 
-// Header 返回头部信息
-func (s *Server) Header() transport.Header {
-	return nil
+// RequestHeader 返回任务 ID、队列与重试次数
+func (tr *Transport) RequestHeader() transport.Header {
+	return tr.reqHeader
 }
 
-func (s *Server) RequestHeader() transport.Header {
-	return nil
+// ReplyHeader 返回回复头，asynq 不会使用
+func (tr *Transport) ReplyHeader() transport.Header {
+	return tr.replyHeader
 }
 
-func (s *Server) ReplyHeader() transport.Header {
-	return nil
+// TaskID 任务 ID
+func (tr *Transport) TaskID() string {
+	return tr.reqHeader.Get(HeaderTaskID)
 }
+
+// Queue 任务所在队列
+func (tr *Transport) Queue() string {
+	return tr.reqHeader.Get(HeaderQueue)
+}
+
+// RetryCount 已重试次数
+func (tr *Transport) RetryCount() int {
+	n, _ := strconv.Atoi(tr.reqHeader.Get(HeaderRetryCount))
+	return n
+}
+
+type headerCarrier http.Header
+
+func (hc headerCarrier) Get(key string) string { return http.Header(hc).Get(key) }
+
+func (hc headerCarrier) Set(key string, value string) { http.Header(hc).Set(key, value) }
+
+func (hc headerCarrier) Add(key string, value string) { http.Header(hc).Add(key, value) }
+
+func (hc headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(hc))
+	for k := range http.Header(hc) {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func (hc headerCarrier) Values(key string) []string { return http.Header(hc).Values(key) }
@@ -0,0 +1,140 @@
+// transport/redisstream/config.go
+package redisstream
+
+import (
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/redis/go-redis/v9"
+)
+
+// Config Redis Streams 消费组配置
+type Config struct {
+	Client redis.UniversalClient `json:"-"`
+	// Group 消费组，同组实例分摊消费
+	Group string `json:"group"`
+	// Consumer 消费者名，默认 hostname-随机串
+	Consumer string `json:"consumer"`
+	// Streams 消费的 stream
+	Streams []string `json:"streams"`
+	// StartID 消费组不存在时从该 ID 开始消费，"$" 只消费新消息，"0" 从头消费
+	StartID     string `json:"start_id"`
+	Concurrency int    `json:"concurrency"`
+	// Block XREADGROUP 阻塞时长
+	Block time.Duration `json:"block"`
+	// MinIdle pending 消息空闲超过该时长后被 XAUTOCLAIM 重新领取
+	MinIdle time.Duration `json:"min_idle"`
+	// ClaimInterval 检查 pending 消息的间隔
+	ClaimInterval time.Duration `json:"claim_interval"`
+	// MaxDeliveries 投递超过该次数仍未确认的消息移入死信 stream，0 表示不限制
+	MaxDeliveries int64 `json:"max_deliveries"`
+	// DeadLetterSuffix 死信 stream 为原 stream 加该后缀
+	DeadLetterSuffix string `json:"dead_letter_suffix"`
+
+	Logger  log.Logger `json:"-"`
+	Handler Handler    `json:"-"`
+	// Middleware 消息处理的中间件链，与 HTTP/gRPC 服务共用
+	Middleware []middleware.Middleware `json:"-"`
+}
+
+// ServerOption Redis Streams 服务器选项
+type ServerOption func(*Config)
+
+// WithClient 设置 Redis 客户端
+func WithClient(cli redis.UniversalClient) ServerOption {
+	return func(c *Config) {
+		c.Client = cli
+	}
+}
+
+// WithGroup 设置消费组
+func WithGroup(group string) ServerOption {
+	return func(c *Config) {
+		c.Group = group
+	}
+}
+
+// WithConsumer 设置消费者名，同一实例重启后沿用同名可接管自己的 pending 消息
+func WithConsumer(consumer string) ServerOption {
+	return func(c *Config) {
+		c.Consumer = consumer
+	}
+}
+
+// WithStreams 设置消费的 stream
+func WithStreams(streams ...string) ServerOption {
+	return func(c *Config) {
+		c.Streams = streams
+	}
+}
+
+// WithStartID 消费组不存在时的起始 ID，默认 "$"
+func WithStartID(id string) ServerOption {
+	return func(c *Config) {
+		c.StartID = id
+	}
+}
+
+// WithConcurrency 设置并发数
+func WithConcurrency(concurrency int) ServerOption {
+	return func(c *Config) {
+		c.Concurrency = concurrency
+	}
+}
+
+// WithBlock 设置 XREADGROUP 阻塞时长，默认5秒
+func WithBlock(block time.Duration) ServerOption {
+	return func(c *Config) {
+		c.Block = block
+	}
+}
+
+// WithMinIdle 设置 pending 消息被重新领取前的空闲时长，默认1分钟，应大于单条消息的处理时间
+func WithMinIdle(minIdle time.Duration) ServerOption {
+	return func(c *Config) {
+		c.MinIdle = minIdle
+	}
+}
+
+// WithClaimInterval 设置检查 pending 消息的间隔，默认30秒
+func WithClaimInterval(interval time.Duration) ServerOption {
+	return func(c *Config) {
+		c.ClaimInterval = interval
+	}
+}
+
+// WithMaxDeliveries 设置最大投递次数，默认5次
+func WithMaxDeliveries(n int64) ServerOption {
+	return func(c *Config) {
+		c.MaxDeliveries = n
+	}
+}
+
+// WithDeadLetterSuffix 设置死信 stream 后缀，默认 ":dead"
+func WithDeadLetterSuffix(suffix string) ServerOption {
+	return func(c *Config) {
+		c.DeadLetterSuffix = suffix
+	}
+}
+
+// WithLogger 设置日志
+func WithLogger(logger log.Logger) ServerOption {
+	return func(c *Config) {
+		c.Logger = logger
+	}
+}
+
+// WithHandler 设置消息处理函数，返回 nil 时确认消息
+func WithHandler(h Handler) ServerOption {
+	return func(c *Config) {
+		c.Handler = h
+	}
+}
+
+// WithMiddleware 设置消息处理的中间件，例如 webkit.ServerLogging、tracing.Server、recovery.Recovery
+func WithMiddleware(m ...middleware.Middleware) ServerOption {
+	return func(c *Config) {
+		c.Middleware = m
+	}
+}
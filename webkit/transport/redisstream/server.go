@@ -0,0 +1,378 @@
+// transport/redisstream/server.go
+package redisstream
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+// claimCount 每次 XAUTOCLAIM 领取的消息数
+const claimCount = 100
+
+// 死信消息在原字段之外附加的来源信息
+const (
+	DeadLetterFieldStream     = "_stream"
+	DeadLetterFieldID         = "_id"
+	DeadLetterFieldGroup      = "_group"
+	DeadLetterFieldDeliveries = "_deliveries"
+)
+
+var _ transport.Server = (*Server)(nil)
+
+// SkipRetry 处理函数返回的错误包装了 SkipRetry 时，消息不再重试，直接移入死信 stream
+var SkipRetry = errors.New("redisstream: skip retry")
+
+// Server Redis Streams 消费组服务器，至少处理一次
+//
+// 一个协程 XREADGROUP 读取新消息、一个协程定期 XAUTOCLAIM 领取超时未确认的消息，
+// 交由 Concurrency 个协程处理；处理成功后 XACK，投递超过 MaxDeliveries 次的消息移入死信 stream
+//
+//	srv := redisstream.NewServer(
+//		redisstream.WithClient(rdb),
+//		redisstream.WithGroup("index-backend"),
+//		redisstream.WithStreams("trade:filled"),
+//		redisstream.WithHandler(redisstream.HandlerFunc(func(ctx context.Context, msg *redisstream.Message) error {
+//			return trade.OnFilled(ctx, msg.String("order_id"))
+//		})),
+//	)
+type Server struct {
+	config   *Config
+	logger   *log.Helper
+	endpoint string
+	handler  middleware.Handler
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewServer 创建 Redis Streams 服务器
+func NewServer(opts ...ServerOption) *Server {
+	hostname, _ := os.Hostname()
+	config := &Config{
+		Consumer:         hostname + "-" + uuid.NewString()[:8],
+		StartID:          "$",
+		Concurrency:      10,
+		Block:            5 * time.Second,
+		MinIdle:          time.Minute,
+		ClaimInterval:    30 * time.Second,
+		MaxDeliveries:    5,
+		DeadLetterSuffix: ":dead",
+		Logger:           log.GetLogger(),
+	}
+
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return &Server{
+		config:   config,
+		logger:   log.NewHelper(config.Logger),
+		endpoint: endpoint(config.Client),
+	}
+}
+
+// Start 创建消费组并启动消费协程
+func (s *Server) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		return nil
+	}
+	switch {
+	case s.config.Client == nil:
+		return errors.New("redisstream: client is required")
+	case s.config.Group == "":
+		return errors.New("redisstream: group is required")
+	case len(s.config.Streams) == 0:
+		return errors.New("redisstream: streams are required")
+	case s.config.Handler == nil:
+		return errors.New("redisstream: handler is required")
+	}
+	if err := s.createGroups(ctx); err != nil {
+		return err
+	}
+
+	s.handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, s.config.Handler.HandleMessage(ctx, req.(*Message))
+	}
+	if len(s.config.Middleware) > 0 {
+		s.handler = middleware.Chain(s.config.Middleware...)(s.handler)
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	msgs := make(chan *Message)
+
+	var producers sync.WaitGroup
+	producers.Add(2)
+	go func() {
+		defer producers.Done()
+		s.fetch(runCtx, msgs)
+	}()
+	go func() {
+		defer producers.Done()
+		s.claim(runCtx, msgs)
+	}()
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		producers.Wait()
+		close(msgs)
+	}()
+	for i := 0; i < s.config.Concurrency; i++ {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			for msg := range msgs {
+				s.process(msg)
+			}
+		}()
+	}
+
+	s.logger.Infof("Redis stream server started: group=%s consumer=%s streams=%v",
+		s.config.Group, s.config.Consumer, s.config.Streams)
+	return nil
+}
+
+// Stop 停止读取新消息，等待处理中的消息结束；已读取未处理的消息留在 pending 列表中，MinIdle 后被重新领取
+func (s *Server) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel == nil {
+		return nil
+	}
+	s.logger.Info("Redis stream server stopping")
+	s.cancel()
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	s.cancel = nil
+	s.logger.Info("Redis stream server stopped")
+	return nil
+}
+
+func (s *Server) createGroups(ctx context.Context) error {
+	for _, stream := range s.config.Streams {
+		err := s.config.Client.XGroupCreateMkStream(ctx, stream, s.config.Group, s.config.StartID).Err()
+		if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+			return errors.Wrapf(err, "redisstream: create group %s on %s", s.config.Group, stream)
+		}
+	}
+	return nil
+}
+
+// fetch 读取新消息直到 ctx 取消
+func (s *Server) fetch(ctx context.Context, msgs chan<- *Message) {
+	streams := make([]string, 0, len(s.config.Streams)*2)
+	streams = append(streams, s.config.Streams...)
+	for range s.config.Streams {
+		streams = append(streams, ">")
+	}
+	for ctx.Err() == nil {
+		res, err := s.config.Client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    s.config.Group,
+			Consumer: s.config.Consumer,
+			Streams:  streams,
+			Count:    int64(s.config.Concurrency),
+			Block:    s.config.Block,
+		}).Result()
+		if err != nil {
+			if errors.Is(err, redis.Nil) || ctx.Err() != nil {
+				continue
+			}
+			s.logger.Errorf("redisstream: xreadgroup: %v", err)
+			// stream 被删除后重建消费组
+			if strings.HasPrefix(err.Error(), "NOGROUP") {
+				if err := s.createGroups(ctx); err != nil {
+					s.logger.Errorf("%v", err)
+				}
+			}
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+		for _, stream := range res {
+			for _, m := range stream.Messages {
+				msg := &Message{Stream: stream.Stream, ID: m.ID, Values: m.Values, Deliveries: 1}
+				if !dispatch(ctx, msgs, msg) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// claim 定期领取空闲超过 MinIdle 的 pending 消息，即处理失败或消费者宕机遗留的消息
+func (s *Server) claim(ctx context.Context, msgs chan<- *Message) {
+	ticker := time.NewTicker(s.config.ClaimInterval)
+	defer ticker.Stop()
+	for {
+		for _, stream := range s.config.Streams {
+			if !s.reclaim(ctx, stream, msgs) {
+				return
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reclaim 领取 stream 中超时的 pending 消息，ctx 取消时返回 false
+func (s *Server) reclaim(ctx context.Context, stream string, msgs chan<- *Message) bool {
+	start := "0-0"
+	for ctx.Err() == nil {
+		claimed, next, err := s.config.Client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   stream,
+			Group:    s.config.Group,
+			Consumer: s.config.Consumer,
+			MinIdle:  s.config.MinIdle,
+			Start:    start,
+			Count:    claimCount,
+		}).Result()
+		if err != nil {
+			if ctx.Err() == nil {
+				s.logger.Errorf("redisstream: xautoclaim %s: %v", stream, err)
+			}
+			return ctx.Err() == nil
+		}
+		deliveries := s.deliveries(ctx, stream, claimed)
+		for _, m := range claimed {
+			msg := &Message{Stream: stream, ID: m.ID, Values: m.Values, Deliveries: deliveries[m.ID]}
+			// 消息已被删除
+			if len(m.Values) == 0 {
+				s.ack(msg)
+				continue
+			}
+			if s.config.MaxDeliveries > 0 && msg.Deliveries > s.config.MaxDeliveries {
+				s.deadLetter(ctx, msg, msg.Deliveries-1)
+				continue
+			}
+			if !dispatch(ctx, msgs, msg) {
+				return false
+			}
+		}
+		if next == "" || next == "0-0" {
+			return true
+		}
+		start = next
+	}
+	return false
+}
+
+// deliveries 查询已领取消息的投递次数，查询失败的消息次数为0，不会移入死信
+func (s *Server) deliveries(ctx context.Context, stream string, claimed []redis.XMessage) map[string]int64 {
+	counts := make(map[string]int64, len(claimed))
+	if len(claimed) == 0 {
+		return counts
+	}
+	pending, err := s.config.Client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream:   stream,
+		Group:    s.config.Group,
+		Consumer: s.config.Consumer,
+		Start:    claimed[0].ID,
+		End:      claimed[len(claimed)-1].ID,
+		// 本消费者在此区间内可能还有处理中的消息
+		Count: int64(len(claimed) + 2*s.config.Concurrency),
+	}).Result()
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Errorf("redisstream: xpending %s: %v", stream, err)
+		}
+		return counts
+	}
+	for _, p := range pending {
+		counts[p.ID] = p.RetryCount
+	}
+	return counts
+}
+
+// deadLetter 将消息连同来源信息写入死信 stream 后确认，写入失败时留待下次领取，failed 为已失败的投递次数
+func (s *Server) deadLetter(ctx context.Context, msg *Message, failed int64) {
+	dead := msg.Stream + s.config.DeadLetterSuffix
+	err := s.config.Client.XAdd(ctx, &redis.XAddArgs{
+		Stream: dead,
+		Values: deadLetterValues(s.config.Group, msg, failed),
+	}).Err()
+	if err != nil {
+		s.logger.Errorf("redisstream: dead letter %s %s: %v", msg.Stream, msg.ID, err)
+		return
+	}
+	s.logger.Warnf("redisstream: message %s %s moved to %s after %d deliveries",
+		msg.Stream, msg.ID, dead, failed)
+	s.ack(msg)
+}
+
+func deadLetterValues(group string, msg *Message, failed int64) map[string]interface{} {
+	values := make(map[string]interface{}, len(msg.Values)+4)
+	for k, v := range msg.Values {
+		values[k] = v
+	}
+	values[DeadLetterFieldStream] = msg.Stream
+	values[DeadLetterFieldID] = msg.ID
+	values[DeadLetterFieldGroup] = group
+	values[DeadLetterFieldDeliveries] = failed
+	return values
+}
+
+// process 经中间件链处理消息，成功后确认，返回 SkipRetry 时移入死信；Stop 时处理中的消息不会被取消
+func (s *Server) process(msg *Message) {
+	ctx := transport.NewServerContext(context.Background(), newTransport(s.endpoint, s.config.Group, msg))
+	if _, err := s.handler(ctx, msg); err != nil {
+		s.logger.WithContext(ctx).Errorf("redisstream: handle %s %s: %+v", msg.Stream, msg.ID, err)
+		if errors.Is(err, SkipRetry) {
+			s.deadLetter(context.Background(), msg, msg.Deliveries)
+		}
+		return
+	}
+	s.ack(msg)
+}
+
+func (s *Server) ack(msg *Message) {
+	if err := s.config.Client.XAck(context.Background(), msg.Stream, s.config.Group, msg.ID).Err(); err != nil {
+		s.logger.Errorf("redisstream: xack %s %s: %v", msg.Stream, msg.ID, err)
+	}
+}
+
+func dispatch(ctx context.Context, msgs chan<- *Message, msg *Message) bool {
+	select {
+	case msgs <- msg:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// endpoint 返回不含密码的 Redis 地址
+func endpoint(cli redis.UniversalClient) string {
+	switch c := cli.(type) {
+	case *redis.Client:
+		return "redis://" + c.Options().Addr
+	case *redis.ClusterClient:
+		return "redis://" + strings.Join(c.Options().Addrs, ",")
+	default:
+		return ""
+	}
+}
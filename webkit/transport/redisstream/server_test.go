@@ -0,0 +1,71 @@
+package redisstream
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func TestServerStartValidate(t *testing.T) {
+	cli := redis.NewClient(&redis.Options{Addr: "127.0.0.1:6379", Password: "secret"})
+	defer cli.Close()
+	handler := HandlerFunc(func(context.Context, *Message) error { return nil })
+
+	tests := []struct {
+		name string
+		opts []ServerOption
+	}{
+		{"no client", []ServerOption{WithGroup("g"), WithStreams("s"), WithHandler(handler)}},
+		{"no group", []ServerOption{WithClient(cli), WithStreams("s"), WithHandler(handler)}},
+		{"no streams", []ServerOption{WithClient(cli), WithGroup("g"), WithHandler(handler)}},
+		{"no handler", []ServerOption{WithClient(cli), WithGroup("g"), WithStreams("s")}},
+	}
+	for _, tt := range tests {
+		if err := NewServer(tt.opts...).Start(context.Background()); err == nil {
+			t.Errorf("%s: want error", tt.name)
+		}
+	}
+
+	s := NewServer(WithClient(cli))
+	if s.endpoint != "redis://127.0.0.1:6379" {
+		t.Errorf("endpoint = %s", s.endpoint)
+	}
+	if err := s.Stop(context.Background()); err != nil {
+		t.Errorf("stop before start: %v", err)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	traceparent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	msg := &Message{
+		Stream:     "trade:filled",
+		ID:         "1-0",
+		Values:     map[string]interface{}{"order_id": "42", "traceparent": traceparent},
+		Deliveries: 3,
+	}
+
+	tr := newTransport("redis://127.0.0.1:6379", "g", msg)
+	if tr.Kind() != KindRedisStream || tr.Operation() != "trade:filled" {
+		t.Errorf("kind = %s, operation = %s", tr.Kind(), tr.Operation())
+	}
+	if tr.MessageID() != "1-0" || tr.Deliveries() != 3 {
+		t.Errorf("message id = %s, deliveries = %d", tr.MessageID(), tr.Deliveries())
+	}
+	if got := tr.RequestHeader().Get("traceparent"); got != traceparent {
+		t.Errorf("traceparent = %q", got)
+	}
+	if got := tr.RequestHeader().Get("order_id"); got != "" {
+		t.Errorf("order_id should not be a header, got %q", got)
+	}
+
+	values := deadLetterValues("g", msg, msg.Deliveries-1)
+	if values["order_id"] != "42" || values[DeadLetterFieldStream] != "trade:filled" ||
+		values[DeadLetterFieldID] != "1-0" || values[DeadLetterFieldGroup] != "g" ||
+		values[DeadLetterFieldDeliveries] != int64(2) {
+		t.Errorf("dead letter values = %v", values)
+	}
+}
@@ -0,0 +1,130 @@
+// transport/redisstream/transport.go
+package redisstream
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-kratos/kratos/v2/transport"
+	"go.opentelemetry.io/otel"
+)
+
+const KindRedisStream transport.Kind = "redisstream"
+
+// 消息信息在 RequestHeader 中的键
+const (
+	HeaderMessageID  = "X-Stream-Message-Id"
+	HeaderGroup      = "X-Stream-Group"
+	HeaderDeliveries = "X-Stream-Deliveries"
+)
+
+// Message stream 中的一条消息
+type Message struct {
+	Stream string
+	ID     string
+	Values map[string]interface{}
+	// Deliveries 含本次在内的投递次数，仅对重新领取的消息准确，新消息为1
+	Deliveries int64
+}
+
+// String 返回字段 key 的字符串值
+func (m *Message) String(key string) string {
+	v, _ := m.Values[key].(string)
+	return v
+}
+
+// Handler 处理一条消息，返回 nil 时确认消息，否则留在 pending 列表中等待重新领取
+type Handler interface {
+	HandleMessage(ctx context.Context, msg *Message) error
+}
+
+// HandlerFunc 函数形式的 Handler
+type HandlerFunc func(ctx context.Context, msg *Message) error
+
+func (f HandlerFunc) HandleMessage(ctx context.Context, msg *Message) error {
+	return f(ctx, msg)
+}
+
+var _ transport.Transporter = (*Transport)(nil)
+
+// Transport 单条消息的 Transporter，Operation 为 stream 名
+type Transport struct {
+	endpoint    string
+	operation   string
+	reqHeader   headerCarrier
+	replyHeader headerCarrier
+}
+
+// newTransport 消息中与 propagator 同名的字段一并写入 RequestHeader，供 tracing.Server 还原链路
+func newTransport(endpoint, group string, msg *Message) *Transport {
+	header := headerCarrier{}
+	header.Set(HeaderMessageID, msg.ID)
+	header.Set(HeaderGroup, group)
+	header.Set(HeaderDeliveries, strconv.FormatInt(msg.Deliveries, 10))
+	for _, field := range otel.GetTextMapPropagator().Fields() {
+		if v := msg.String(field); v != "" {
+			header.Set(field, v)
+		}
+	}
+	return &Transport{
+		endpoint:    endpoint,
+		operation:   msg.Stream,
+		reqHeader:   header,
+		replyHeader: headerCarrier{},
+	}
+}
+
+// Kind 返回传输类型
+func (tr *Transport) Kind() transport.Kind {
+	return KindRedisStream
+}
+
+// Endpoint 返回 Redis 地址
+func (tr *Transport) Endpoint() string {
+	return tr.endpoint
+}
+
+// Operation 返回 stream 名
+func (tr *Transport) Operation() string {
+	return tr.operation
+}
+
+// RequestHeader 返回消息 ID、消费组、投递次数与链路信息
+func (tr *Transport) RequestHeader() transport.Header {
+	return tr.reqHeader
+}
+
+// ReplyHeader 返回回复头，stream 不会使用
+func (tr *Transport) ReplyHeader() transport.Header {
+	return tr.replyHeader
+}
+
+// MessageID 消息 ID
+func (tr *Transport) MessageID() string {
+	return tr.reqHeader.Get(HeaderMessageID)
+}
+
+// Deliveries 含本次在内的投递次数
+func (tr *Transport) Deliveries() int64 {
+	n, _ := strconv.ParseInt(tr.reqHeader.Get(HeaderDeliveries), 10, 64)
+	return n
+}
+
+type headerCarrier http.Header
+
+func (hc headerCarrier) Get(key string) string { return http.Header(hc).Get(key) }
+
+func (hc headerCarrier) Set(key string, value string) { http.Header(hc).Set(key, value) }
+
+func (hc headerCarrier) Add(key string, value string) { http.Header(hc).Add(key, value) }
+
+func (hc headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(hc))
+	for k := range http.Header(hc) {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func (hc headerCarrier) Values(key string) []string { return http.Header(hc).Values(key) }